@@ -2,10 +2,18 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
 	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,48 +22,177 @@ import (
 // An Account object manages the state and provides methods for blockchain interactions
 // such as managing accounts, submitting certificates, and querying transactions.
 // It maintains internal configurations like the current network and blockchain address.
+//
+// Submissions are serialized per account: concurrent callers queue, so each one
+// builds its transaction with the nonce left by the previous one, and nonce updates
+// by UpdateAccount wait for the submission in progress. Queries such as
+// GetTransactionByID do not queue and run concurrently with submissions. The setters
+// are not safe for concurrent use and are meant to configure the account up front.
 type Account struct {
+	accountSettings
+
+	// nonce holds the account's current Nonce, set by UpdateAccount and advanced by each
+	// successful submission.
+	nonce string
+	// lastError stores the most recent error message encountered by an account operation.
+	lastError string
+	// dedup holds recent submissions while deduplication is enabled.
+	dedup dedupCache
+	// lifecycleMu guards shuttingDown and registration of in-flight submissions.
+	lifecycleMu sync.Mutex
+	// shuttingDown is set by Shutdown to reject new submissions.
+	shuttingDown bool
+	// inflight counts submissions in progress, drained by Shutdown.
+	inflight sync.WaitGroup
+	// nonceUpdatedAt is when UpdateAccount last refreshed the nonce.
+	nonceUpdatedAt time.Time
+	// nonceManager, when set, supplies the nonce of each transaction built.
+	nonceManager *NonceManager
+	// latestTxID and latestBlock locate the latest entry appended with AppendToLog.
+	latestTxID  string
+	latestBlock string
+	// submitMu serializes submissions and nonce updates; nonce reads hold its read lock.
+	submitMu sync.RWMutex
+	// watchers tracks running subscriptions, stopped by StopAllWatchers.
+	watchers watcherRegistry
+	// closeMu serializes Close.
+	closeMu sync.Mutex
+	// closed is set by Close once the client's resources are released, and cleared
+	// when a new client is created.
+	closed bool
+	// versionWarning logs a protocol version mismatch once.
+	versionWarning sync.Once
+}
+
+// accountSettings holds the configuration of an Account, which WithBlockchain carries
+// over to the copies it makes. Settings belong here; state specific to an account,
+// such as its nonce, belongs in Account.
+type accountSettings struct {
 	// nagURL stores the Network Access Gateway URL for API requests.
 	nagURL string
 	// network specifies the currently configured blockchain network (e.g., "testnet", "devnet", "mainnet").
 	network string
 	// blockchain specifies the currently configured blockchain address where certificates are managed.
 	blockchain string
-	// nonce holds the account's current Nonce, which is updated by calling UpdateAccount.
-	nonce string
-	// lastError stores the most recent error message encountered by an account operation.
-	lastError string
 	// client is the HTTP client used for network requests
 	client *client.Client
 	// config holds the network configuration
 	config *Config
 	// walletAddress holds the current wallet address
 	walletAddress string
+	// signingKey holds the private key cached by CacheSigningKey, if any.
+	signingKey *btcec.PrivateKey
+	// signingKeyID identifies the private key string signingKey was parsed from.
+	signingKeyID [32]byte
+	// protocolVersion holds the NAG protocol version last reported by ProtocolVersion.
+	protocolVersion string
+	// versionTolerance is the number of minor versions CheckCompatibility tolerates.
+	versionTolerance int
+	// streamURL is the Server-Sent Events endpoint used by SubscribeTransactions.
+	streamURL string
+	// dedupWindow is how long successful submissions are remembered for deduplication.
+	dedupWindow time.Duration
+	// maxPollAttempts caps the lookups made by GetTransactionOutcome; zero means unlimited.
+	maxPollAttempts int
+	// debug records raw NAG exchanges while debug capture is enabled.
+	debug *exchangeRecorder
+	// signer, when set, signs submitted transactions instead of a raw private key.
+	signer Signer
+	// recoverable makes SignData produce 65-byte recoverable signatures instead of DER.
+	recoverable bool
+	// txIDFieldOrder overrides the order of the fields hashed into transaction IDs.
+	txIDFieldOrder []string
+	// maxNonce is the largest nonce transactions are built with; zero means the default.
+	maxNonce uint64
+	// strictDecoding rejects unknown fields in typed NAG responses.
+	strictDecoding bool
+	// allowEmptyData permits certificates with empty data to be submitted.
+	allowEmptyData bool
+	// nonceStaleness is how long SubmitCertificateAutoNonce trusts a refreshed nonce.
+	nonceStaleness time.Duration
+	// timeout is the NAG request timeout; zero means the package default.
+	timeout time.Duration
+	// maxReaderSize caps the data read by SubmitCertificateReader; zero means the default.
+	maxReaderSize int64
+	// streamBuffer is how many items SubmitStream reads ahead of sending them.
+	streamBuffer int
+	// clockCorrection makes CheckClockSkew correct transaction timestamps.
+	clockCorrection bool
+	// clockOffset is the correction applied to transaction timestamps.
+	clockOffset time.Duration
+	// maxBlockRange caps the blocks fetched by GetBlockRange; zero means the default.
+	maxBlockRange int
+	// maxConcurrent bounds the NAG requests in flight at once; zero means unbounded.
+	maxConcurrent int
+	// codec encodes requests and decodes responses; nil means JSON.
+	codec Codec
+	// requireHTTPS, when set, overrides whether NAG URLs must use HTTPS; by default
+	// only mainnet requires it.
+	requireHTTPS *bool
 }
 
+// newClient creates the NAG client for baseURL, applying the account's client settings.
+func (a *Account) newClient(baseURL string) *client.Client {
+	c := client.NewClient(baseURL)
+	a.applyTimeout(c)
+	c.SetMaxConcurrentRequests(a.maxConcurrent)
+	if a.codec != nil {
+		c.SetCodec(a.codec)
+	}
+	if a.config != nil && a.config.Client != nil {
+		a.config.Client.apply(c)
+	}
+	a.closed = false
+	if a.debug != nil {
+		c.SetObserver(a.debug.record)
+	}
+	return c
+}
+
+// timestamp returns the timestamp of new transactions; tests replace it to make
+// transaction IDs reproducible.
+var timestamp = utils.GetFormattedTimeStamp
+
+// networkDiscoveryURL is the base URL of the service resolving a network name to its NAG URL.
+var networkDiscoveryURL = "https://circularlabs.io"
+
 // NewAccount creates a new Account instance
+//
+// The account inherits the request timeout set with SetDefaultTimeout.
 func NewAccount() *Account {
-	return &Account{}
+	return &Account{accountSettings: accountSettings{timeout: time.Duration(defaultTimeout.Load())}}
 }
 
 // NewAccountWithConfig creates a new Account instance with network configuration
+//
+// The optional client section of the configuration tunes the NAG clients the account
+// creates, e.g. in SetNetwork.
 func NewAccountWithConfig(configPath string) (*Account, error) {
 	config, err := LoadConfig(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &Account{
-		config: config,
-	}, nil
+	account := &Account{accountSettings: accountSettings{
+		config:  config,
+		timeout: time.Duration(defaultTimeout.Load()),
+	}}
+	if config.Client != nil && config.Client.timeout > 0 {
+		account.timeout = config.Client.timeout
+	}
+	return account, nil
 }
 
 // Open initializes the Account instance with a given blockchain address.
 //
 // The address parameter is the wallet address associated with this account.
 // This method prepares the account for subsequent interactions with the network.
-// It returns an error if the account cannot be opened or initialized.
+// It returns an error if the account cannot be opened or initialized, and
+// ValidationErrors if the address is empty or contains whitespace.
 func (a *Account) Open(address string) error {
+	if err := validateAddress("address", address).asError(); err != nil {
+		return err
+	}
 	a.walletAddress = address
 	return nil
 }
@@ -66,6 +203,13 @@ func (a *Account) Open(address string) error {
 // is typically called before submitting new certificates or transactions.
 // It returns true if the nonce was successfully updated, false otherwise, along with an error.
 func (a *Account) UpdateAccount() (bool, error) {
+	a.submitMu.Lock()
+	defer a.submitMu.Unlock()
+	return a.updateAccount()
+}
+
+// updateAccount refreshes the nonce. The caller must hold the submission lock.
+func (a *Account) updateAccount() (bool, error) {
 	if a.walletAddress == "" {
 		return false, fmt.Errorf("account is not open")
 	}
@@ -73,41 +217,74 @@ func (a *Account) UpdateAccount() (bool, error) {
 	// If no client, we're in test mode
 	if a.client == nil {
 		a.nonce = "299" // Example nonce for testing
+		a.nonceUpdatedAt = time.Now()
+		a.reseedNonceManager()
 		return true, nil
 	}
 
+	nonce, err := a.fetchWalletNonce()
+	if err != nil {
+		return false, err
+	}
+	a.nonce = nonce.Add(nonce, big.NewInt(1)).String()
+	a.nonceUpdatedAt = time.Now()
+	a.reseedNonceManager()
+	return true, nil
+}
+
+// reseedNonceManager makes the nonce manager, if any, continue from the account
+// nonce. Nonces too large for an int leave it unchanged.
+func (a *Account) reseedNonceManager() {
+	if a.nonceManager == nil {
+		return
+	}
+	if nonce, err := strconv.Atoi(a.nonce); err == nil {
+		a.nonceManager.Set(nonce)
+	}
+}
+
+// fetchWalletNonce returns the nonce the network reports for the wallet, which is the
+// nonce of its last processed transaction.
+func (a *Account) fetchWalletNonce() (*big.Int, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
 	// Real API call matching NodeJS implementation
 	payload := map[string]interface{}{
 		"Blockchain": utils.HexFix(a.blockchain),
 		"Address":    utils.HexFix(a.walletAddress),
-		"Version":    "1.0.1",
+		"Version":    libVersion,
 	}
 
 	ctx := context.Background()
 	response, err := a.client.POST(ctx, "Circular_GetWalletNonce_"+a.network, payload)
 	if err != nil {
 		a.lastError = err.Error()
-		return false, fmt.Errorf("failed to update account: %w", err)
+		return nil, fmt.Errorf("failed to update account: %w", err)
 	}
 
 	// Parse response to extract nonce
 	var result struct {
 		Result   int `json:"Result"`
 		Response struct {
-			Nonce int `json:"Nonce"`
+			Nonce json.Number `json:"Nonce"`
 		} `json:"Response"`
 	}
 	
-	if err := json.Unmarshal(response, &result); err != nil {
-		return false, fmt.Errorf("failed to parse response: %w", err)
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if result.Result == 200 && result.Response.Nonce >= 0 {
-		a.nonce = fmt.Sprintf("%d", result.Response.Nonce+1)
-		return true, nil
+	if result.Result == 200 {
+		// Keep the nonce as an arbitrary-precision integer so large values stay exact.
+		nonce, ok := new(big.Int).SetString(result.Response.Nonce.String(), 10)
+		if ok && nonce.Sign() >= 0 {
+			return nonce, nil
+		}
 	}
 
-	return false, fmt.Errorf("invalid response format or missing Nonce field")
+	return nil, fmt.Errorf("invalid response format or missing Nonce field")
 }
 
 // SetNetwork configures the blockchain network for the account.
@@ -115,11 +292,18 @@ func (a *Account) UpdateAccount() (bool, error) {
 // The network parameter specifies which network to interact with (e.g., "testnet",
 // "devnet", or "mainnet").
 // No explicit return value is documented for the original API, implying it's a setter function.
+// It returns ValidationErrors if the network name is empty or contains characters
+// other than letters, digits, '-' and '_', and an error wrapping ErrInsecureNAGURL
+// if the resolved NAG URL is not HTTPS while HTTPS is required (see SetRequireHTTPS).
 func (a *Account) SetNetwork(network string) error {
+	if err := validateNetwork(network).asError(); err != nil {
+		return err
+	}
 	a.network = network
 	
 	// Create temporary client for network lookup
-	tempClient := client.NewClient("https://circularlabs.io")
+	tempClient := client.NewClient(networkDiscoveryURL)
+	a.applyTimeout(tempClient)
 	ctx := context.Background()
 	
 	response, err := tempClient.GET(ctx, "/network/getNAG?network="+network)
@@ -128,9 +312,7 @@ func (a *Account) SetNetwork(network string) error {
 		if a.config != nil {
 			nagURL := a.config.GetNAGURL(network)
 			if nagURL != "" {
-				a.nagURL = nagURL
-				a.client = client.NewClient(nagURL)
-				return nil
+				return a.useNAG(network, nagURL)
 			}
 		}
 		return fmt.Errorf("failed to fetch network URL: %w", err)
@@ -142,14 +324,12 @@ func (a *Account) SetNetwork(network string) error {
 		Message string `json:"message"`
 	}
 	
-	if err := json.Unmarshal(response, &result); err != nil {
+	if err := a.decode(response, &result); err != nil {
 		return fmt.Errorf("failed to parse network response: %w", err)
 	}
 	
 	if result.Status == "success" && result.URL != "" {
-		a.nagURL = result.URL
-		a.client = client.NewClient(result.URL)
-		return nil
+		return a.useNAG(network, result.URL)
 	}
 	
 	return fmt.Errorf("failed to get network URL: %s", result.Message)
@@ -168,27 +348,60 @@ func (a *Account) SetBlockchain(chain string) {
 //
 // This method should be called to clean up any resources or connections
 // associated with the account, ensuring a proper shutdown.
+// Close is idempotent and safe to call concurrently: the idle connections of the
+// NAG client are closed once, and every call leaves the account fully reset.
+// Running subscriptions are stopped first, as with StopAllWatchers.
 // No explicit return value is documented.
 func (a *Account) Close() {
+	a.closeMu.Lock()
+	defer a.closeMu.Unlock()
+
+	a.StopAllWatchers()
+
+	if !a.closed && a.client != nil {
+		a.client.CloseIdleConnections()
+	}
+	a.closed = true
+
 	// Reset internal state for cleanup.
 	a.nagURL = ""
 	a.network = ""
 	a.blockchain = ""
 	a.nonce = ""
 	a.lastError = ""
+	if a.signingKey != nil {
+		// Best-effort wipe; see wipeBytes for the limitations.
+		a.signingKey.Zero()
+	}
+	a.signingKey = nil
+	a.signingKeyID = [32]byte{}
+	a.protocolVersion = ""
+	a.nonceUpdatedAt = time.Time{}
 }
 
 // SignData signs the provided data using the account's private key.
 //
 // The data parameter is the content (as a byte slice) to be cryptographically
 // signed. The privateKey is the string representation of the account's private key.
-// It returns the signed data as a byte slice and an error if the signing process fails.
+// The data is hashed with SHA-256 and signed with ECDSA over secp256k1; privateKey is
+// the hex-encoded key. If a key was cached with CacheSigningKey and privateKey is empty
+// or matches it, the cached key is used. Otherwise the key is decoded for this call only
+// and its memory is zeroed afterwards on a best-effort basis.
+// It returns the DER-encoded signature as a byte slice and an error if the signing process fails.
+// When recoverable signatures are enabled with SetRecoverableSignatures, the 65-byte
+// compact recoverable signature is returned instead.
 func (a *Account) SignData(data []byte, privateKey string) ([]byte, error) {
-	// In a full implementation, this would involve cryptographic signing operations (e.g., ECDSA).
-	// For now, we'll simulate signing by prefixing the data with a signature marker
-	signaturePrefix := "SIGNED_" + utils.StringToHex(privateKey) + "_"
-	signedData := append([]byte(signaturePrefix), data...)
-	return signedData, nil
+	key, cached, err := a.signingKeyFor(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		defer key.Zero()
+	}
+	if a.recoverable {
+		return signMessageRecoverable(key, data), nil
+	}
+	return signMessage(key, data), nil
 }
 
 // SubmitCertificate submits the given data as a certificate to the blockchain.
@@ -197,7 +410,31 @@ func (a *Account) SignData(data []byte, privateKey string) ([]byte, error) {
 // The privateKey is used to authorize and sign the transaction on the blockchain.
 // It returns a pointer to a SubmitCertificateResponse containing the transaction ID and timestamp
 // upon success, or an error if the submission fails.
+// A successful submission advances the account nonce, so consecutive and concurrent
+// submissions carry consecutive nonces.
+// Invalid arguments are reported as ValidationErrors; empty data, in particular, is
+// rejected with an error matching ErrEmptyData unless allowed with SetAllowEmptyData.
 func (a *Account) SubmitCertificate(pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	return a.submitValidated(pdata, privateKey)
+}
+
+// submitValidated submits validated certificate data, deduplicating it if enabled.
+// The caller must hold the submission lock.
+func (a *Account) submitValidated(pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	if a.client != nil {
+		return a.deduplicate(pdata, func() (*SubmitCertificateResponse, error) {
+			return a.submitCertificate(pdata, privateKey)
+		})
+	}
+
 	// In a full implementation, this would involve constructing and sending an API request.
 	// The response structure is based on the "Expected Result" from source.
 	resp := &SubmitCertificateResponse{
@@ -224,6 +461,10 @@ func (a *Account) SubmitCertificate(pdata []byte, privateKey string) (*SubmitCer
 // the transaction outcome.
 // It returns a pointer to a TransactionResponse with detailed transaction information, or an error.
 func (a *Account) GetTransactionOutcome(txID string, timeoutSec int) (*TransactionResponse, error) {
+	if a.client != nil {
+		return a.pollTransactionOutcome(txID, timeoutSec)
+	}
+
 	// In a full implementation, this would involve continuous polling with delays.
 	// The response structure is comprehensive based on "Expected Result" from source.
 	resp := &TransactionResponse{
@@ -283,6 +524,10 @@ func (a *Account) GetTransactionOutcome(txID string, timeoutSec int) (*Transacti
 // it might be left empty or represent a timestamp/block range for broader searches.
 // It returns a pointer to a TransactionResponse containing the transaction details, or an error.
 func (a *Account) GetTransactionByID(txID, start, end string) (*TransactionResponse, error) {
+	if a.client != nil {
+		return a.fetchTransaction(txID, start, end)
+	}
+
 	// In a full implementation, this would query the blockchain explorer or API endpoint.
 	// The response structure is comprehensive based on "Expected Result" from source.
 	resp := &TransactionResponse{
@@ -329,4 +574,303 @@ func (a *Account) GetTransactionByID(txID, start, end string) (*TransactionRespo
 		Node: "selected_node",
 	}
 	return resp, nil
-}
\ No newline at end of file
+}
+
+// submitCertificate builds, signs and sends a certificate transaction to the NAG.
+func (a *Account) submitCertificate(pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	_, result, err := a.submitPayload(newCertificatePayload(pdata), privateKey)
+	return result, err
+}
+
+// submitPayload builds, signs and sends a transaction carrying payload to the NAG,
+// advancing the account nonce once it is accepted. It returns the transaction once
+// built, even if sending it fails. The caller must hold the submission lock.
+func (a *Account) submitPayload(payload certificatePayload, privateKey string) (*SignedTransaction, *SubmitCertificateResponse, error) {
+	if a.walletAddress == "" {
+		return nil, nil, fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := a.buildTransaction(payload, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, _, err := a.sendTransaction(tx)
+	if err == nil {
+		a.consumeNonce(tx)
+	}
+	return tx, result, err
+}
+
+// sendTransaction posts a signed transaction to the NAG. It returns the parsed
+// acknowledgment along with the raw response body.
+func (a *Account) sendTransaction(tx *SignedTransaction) (*SubmitCertificateResponse, []byte, error) {
+	a.warnOnVersionMismatch()
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_AddTransaction_"+a.network, tx)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, nil, fmt.Errorf("failed to submit certificate: %w", err)
+	}
+
+	var result SubmitCertificateResponse
+	if err := a.decodeTyped(response, &result); err != nil {
+		return nil, response, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return &result, response, fmt.Errorf("certificate submission failed (result %d): %s", result.Result, result.Message)
+	}
+	return &result, response, nil
+}
+
+// buildCertificateTransaction wraps pdata in a certificate payload and signs it,
+// following the NodeJS submitCertificate implementation: the transaction ID is the
+// SHA-256 of the signing preimage, and the signature covers the ID.
+func (a *Account) buildCertificateTransaction(pdata []byte, privateKey string) (*SignedTransaction, error) {
+	return a.buildTransaction(newCertificatePayload(pdata), privateKey)
+}
+
+// buildTransaction creates the transaction carrying payload and signs it.
+func (a *Account) buildTransaction(payload certificatePayload, privateKey string) (*SignedTransaction, error) {
+	tx, err := a.newTransaction(payload)
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature, err = a.signTransactionID(tx.ID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// certificatePayload is the JSON object carried, hex-encoded, in the Payload of a
+// certificate transaction.
+type certificatePayload struct {
+	Action string `json:"Action"`         // Always "CP_CERTIFICATE".
+	Data   string `json:"Data"`           // The hex-encoded certificate data.
+	Memo   string `json:"Memo,omitempty"` // An optional human-readable memo.
+	// Meta optionally describes the data so recipients can render it.
+	Meta *certificateMeta `json:"Meta,omitempty"`
+}
+
+// certificateMeta is the metadata header of certificates submitted with
+// SubmitCertificateWithMeta.
+type certificateMeta struct {
+	MimeType string `json:"MimeType"`           // The media type of the data, e.g. "application/pdf".
+	Filename string `json:"Filename,omitempty"` // The original name of the file holding the data.
+}
+
+// newCertificatePayload wraps pdata in a certificate payload.
+func newCertificatePayload(pdata []byte) certificatePayload {
+	return certificatePayload{Action: "CP_CERTIFICATE", Data: utils.StringToHex(string(pdata))}
+}
+
+// newCertificateTransaction builds the unsigned certificate transaction for pdata,
+// including its ID.
+func (a *Account) newCertificateTransaction(pdata []byte) (*SignedTransaction, error) {
+	return a.newTransaction(newCertificatePayload(pdata))
+}
+
+// newTransaction builds the unsigned transaction carrying payload, including its ID.
+// It returns an error if the certificate data is empty and not allowed, or the
+// account nonce is implausible.
+func (a *Account) newTransaction(payload certificatePayload) (*SignedTransaction, error) {
+	return a.newTransactionAt(payload, a.transactionTimestamp())
+}
+
+// newTransactionAt is like newTransaction but stamps the transaction with ts.
+func (a *Account) newTransactionAt(payload certificatePayload, ts string) (*SignedTransaction, error) {
+	return a.unsignedTransaction(payload, ts, true)
+}
+
+// previewTransaction builds the transaction newTransaction would build for payload,
+// without drawing its nonce from the nonce manager or changing the account.
+func (a *Account) previewTransaction(payload certificatePayload) (*SignedTransaction, error) {
+	return a.unsignedTransaction(payload, a.transactionTimestamp(), false)
+}
+
+// unsignedTransaction builds the unsigned transaction carrying payload, stamped with
+// ts. With a nonce manager set, the transaction carries its next nonce, which is
+// drawn, and recorded as the account nonce, only if draw is set.
+func (a *Account) unsignedTransaction(payload certificatePayload, ts string, draw bool) (*SignedTransaction, error) {
+	if payload.Data == "" && !a.allowEmptyData {
+		return nil, emptyDataError()
+	}
+	nonce := a.nonce
+	if a.nonceManager != nil {
+		nonce = strconv.Itoa(a.nonceManager.peek())
+	}
+	if err := a.checkNonce(nonce); err != nil {
+		return nil, err
+	}
+	if draw && a.nonceManager != nil {
+		nonce = strconv.Itoa(a.nonceManager.Next())
+		a.nonce = nonce
+	}
+
+	certificate, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificate payload: %w", err)
+	}
+
+	tx := &SignedTransaction{
+		From:       utils.HexFix(a.walletAddress),
+		To:         utils.HexFix(a.walletAddress),
+		Timestamp:  ts,
+		Payload:    utils.StringToHex(string(certificate)),
+		Nonce:      nonce,
+		Blockchain: utils.HexFix(a.blockchain),
+		Type:       "C_TYPE_CERTIFICATE",
+		Version:    libVersion,
+	}
+
+	hash := sha256.Sum256([]byte(a.SigningPreimage(tx)))
+	tx.ID = hex.EncodeToString(hash[:])
+	return tx, nil
+}
+
+// ErrEmptyData is returned when submitting a certificate with empty data, which
+// would anchor a meaningless certificate while still incurring fees.
+var ErrEmptyData = errors.New("certificate data is empty: call SetAllowEmptyData to submit it anyway")
+
+// SetAllowEmptyData permits certificates with empty data to be submitted. They are
+// rejected with ErrEmptyData by default.
+func (a *Account) SetAllowEmptyData(allow bool) {
+	a.allowEmptyData = allow
+}
+
+// pollInterval is the delay between transaction lookups while waiting for an outcome.
+var pollInterval = 2 * time.Second
+
+// errTransactionNotFound is returned by fetchTransaction when the NAG does not
+// (yet) know about the requested transaction.
+var errTransactionNotFound = errors.New("transaction not found")
+
+// ErrOutcomeTimeout is returned by GetTransactionOutcome when the transaction is
+// still pending once the timeout has elapsed.
+var ErrOutcomeTimeout = errors.New("timeout exceeded")
+
+// ErrMaxPollAttempts is returned by GetTransactionOutcome when the transaction is
+// still pending after the number of lookups set with SetMaxPollAttempts.
+var ErrMaxPollAttempts = errors.New("maximum polling attempts exceeded")
+
+// SetMaxPollAttempts caps the number of lookups GetTransactionOutcome makes, so that
+// polling stops after n attempts even if the timeout has not elapsed. Zero (the
+// default) means unlimited.
+func (a *Account) SetMaxPollAttempts(n int) {
+	a.maxPollAttempts = n
+}
+
+// fetchTransaction queries the NAG for a transaction by its ID.
+func (a *Account) fetchTransaction(txID, start, end string) (*TransactionResponse, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"ID":         utils.HexFix(txID),
+		"Start":      start,
+		"End":        end,
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetTransactionbyID_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	// The NAG reports an unknown transaction as a plain string in the Response
+	// field, so peek at it before decoding into the typed structure.
+	var envelope struct {
+		Result   int             `json:"Result"`
+		Response json.RawMessage `json:"Response"`
+		Message  string          `json:"message"`
+	}
+	if err := a.decode(response, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if envelope.Result != 200 {
+		return nil, fmt.Errorf("transaction lookup failed (result %d): %s", envelope.Result, envelope.Message)
+	}
+	if len(envelope.Response) == 0 || envelope.Response[0] != '{' {
+		return nil, errTransactionNotFound
+	}
+
+	var result TransactionResponse
+	if err := a.decodeTyped(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	result.Response.Status = normalizeStatus(result.Response.Status)
+	return &result, nil
+}
+
+// pollTransactionOutcome looks up txID until it leaves the "Pending" state, timeoutSec
+// elapses or the maximum number of attempts is reached. The first lookup happens
+// immediately, so a transaction that is already confirmed returns without waiting
+// for a poll interval.
+func (a *Account) pollTransactionOutcome(txID string, timeoutSec int) (*TransactionResponse, error) {
+	resp, done, err := a.checkTransactionOutcome(txID)
+	if done {
+		return resp, err
+	}
+	attempts := 1
+
+	timeout := time.NewTimer(time.Duration(timeoutSec) * time.Second)
+	defer timeout.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeout.C:
+			return nil, fmt.Errorf("%w waiting for transaction %s", ErrOutcomeTimeout, txID)
+		case <-ticker.C:
+			if a.maxPollAttempts > 0 && attempts >= a.maxPollAttempts {
+				return nil, fmt.Errorf("transaction %s: %w (%d)", txID, ErrMaxPollAttempts, a.maxPollAttempts)
+			}
+			resp, done, err := a.checkTransactionOutcome(txID)
+			if done {
+				return resp, err
+			}
+			attempts++
+		}
+	}
+}
+
+// checkTransactionOutcome performs a single outcome lookup. done reports whether
+// polling should stop, either because the transaction is no longer pending or
+// because the lookup failed for a reason other than the transaction being unknown.
+func (a *Account) checkTransactionOutcome(txID string) (resp *TransactionResponse, done bool, err error) {
+	resp, err = a.fetchTransaction(txID, "0", "10")
+	if errors.Is(err, errTransactionNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	if strings.EqualFold(resp.Response.Status, "Pending") {
+		return nil, false, nil
+	}
+	return resp, true, nil
+}
+
+// knownStatuses are the canonical spellings of the transaction statuses reported by
+// NAGs, which do not all use the same casing.
+var knownStatuses = []string{"Pending", "Executed", "Failed", "Rejected"}
+
+// normalizeStatus returns the canonical spelling of a transaction status, matching
+// case-insensitively; unknown statuses are returned unchanged.
+func normalizeStatus(status string) string {
+	for _, known := range knownStatuses {
+		if strings.EqualFold(status, known) {
+			return known
+		}
+	}
+	return status
+}
@@ -1,9 +1,43 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
 )
 
+// newMockNAGAccount returns an open testnet account whose client talks to an
+// httptest server running handler.
+func newMockNAGAccount(t *testing.T, handler http.HandlerFunc) *Account {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	account := NewAccount()
+	account.Open("0xtest_wallet_address")
+	account.network = "testnet"
+	account.SetBlockchain("0xtest_blockchain")
+	account.nagURL = server.URL
+	account.client = client.NewClient(server.URL)
+	account.client.SetRetryDelay(time.Millisecond)
+	return account
+}
+
+// writeNAGResponse encodes body as the JSON reply of a mock NAG.
+func writeNAGResponse(t *testing.T, w http.ResponseWriter, body interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		t.Errorf("failed to encode mock NAG response: %v", err)
+	}
+}
+
 func TestAccount_Open(t *testing.T) {
 	account := &Account{}
 	address := "test_wallet_address_123"
@@ -77,11 +111,13 @@ func TestAccount_SetBlockchain(t *testing.T) {
 
 func TestAccount_Close(t *testing.T) {
 	account := &Account{
-		nagURL:     "https://test.nag.url",
-		network:    "testnet",
-		blockchain: "test_blockchain",
-		nonce:      "123",
-		lastError:  "test error",
+		accountSettings: accountSettings{
+			nagURL:     "https://test.nag.url",
+			network:    "testnet",
+			blockchain: "test_blockchain",
+		},
+		nonce:     "123",
+		lastError: "test error",
 	}
 	
 	account.Close()
@@ -106,6 +142,7 @@ func TestAccount_Close(t *testing.T) {
 
 func TestAccount_UpdateAccount(t *testing.T) {
 	account := &Account{}
+	account.Open("test_address")
 	
 	success, err := account.UpdateAccount()
 	
@@ -158,7 +195,7 @@ func TestAccount_ChainedOperations(t *testing.T) {
 func TestAccount_SignData(t *testing.T) {
 	account := &Account{}
 	testData := []byte("test data to sign")
-	privateKey := "test_private_key_123"
+	privateKey := testPrivateKey
 	
 	signedData, err := account.SignData(testData, privateKey)
 	if err != nil {
@@ -276,4 +313,179 @@ func TestAccount_GetTransactionOutcomeTimeout(t *testing.T) {
 	if response == nil {
 		t.Error("GetTransactionOutcome should return response even with zero timeout")
 	}
-}
\ No newline at end of file
+}
+
+func TestAccount_GetTransactionOutcomeAlreadyConfirmed(t *testing.T) {
+	calls := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"ID": "abc123", "Status": "Executed", "BlockID": "block_1"},
+		})
+	})
+
+	// A poll interval far longer than the test allows proves no tick was awaited.
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = time.Hour
+
+	start := time.Now()
+	response, err := account.GetTransactionOutcome("abc123", 30)
+	if err != nil {
+		t.Fatalf("GetTransactionOutcome failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetTransactionOutcome should return immediately for a confirmed transaction, took %v", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("Expected exactly 1 NAG call, got %d", calls)
+	}
+	if response.Response.Status != "Executed" {
+		t.Errorf("Expected status Executed, got %q", response.Response.Status)
+	}
+}
+
+func TestAccount_GetTransactionOutcomePolling(t *testing.T) {
+	calls := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": "Transaction Not Found"})
+		case 2:
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": "Pending"}})
+		default:
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": "Executed"}})
+		}
+	})
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	response, err := account.GetTransactionOutcome("abc123", 5)
+	if err != nil {
+		t.Fatalf("GetTransactionOutcome failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 NAG calls, got %d", calls)
+	}
+	if response.Response.Status != "Executed" {
+		t.Errorf("Expected status Executed, got %q", response.Response.Status)
+	}
+}
+
+func TestAccount_GetTransactionOutcomeStatusCasing(t *testing.T) {
+	for _, pending := range []string{"pending", "PENDING", "Pending", "pEnDiNg"} {
+		t.Run(pending, func(t *testing.T) {
+			calls := 0
+			account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				status := pending
+				if calls > 2 {
+					status = "EXECUTED"
+				}
+				writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": status}})
+			})
+
+			defer func(d time.Duration) { pollInterval = d }(pollInterval)
+			pollInterval = 10 * time.Millisecond
+
+			response, err := account.GetTransactionOutcome("abc123", 5)
+			if err != nil {
+				t.Fatalf("GetTransactionOutcome failed: %v", err)
+			}
+			if calls != 3 {
+				t.Errorf("Expected polling to continue while %q, got %d NAG calls", pending, calls)
+			}
+			if response.Response.Status != "Executed" {
+				t.Errorf("Expected the normalized status Executed, got %q", response.Response.Status)
+			}
+		})
+	}
+}
+
+func TestAccount_GetTransactionOutcomeTimeoutExceeded(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": "Pending"}})
+	})
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	if _, err := account.GetTransactionOutcome("abc123", 1); err == nil {
+		t.Error("GetTransactionOutcome should time out for a transaction that stays pending")
+	}
+}
+
+func TestAccount_SetMaxPollAttempts(t *testing.T) {
+	calls := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": "Pending"}})
+	})
+	account.SetMaxPollAttempts(3)
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 5 * time.Millisecond
+
+	_, err := account.GetTransactionOutcome("abc123", 60)
+	if !errors.Is(err, ErrMaxPollAttempts) {
+		t.Fatalf("Expected ErrMaxPollAttempts, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected polling to stop after 3 attempts, got %d", calls)
+	}
+}
+
+func TestAccount_SetMaxPollAttemptsUnlimited(t *testing.T) {
+	calls := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := "Pending"
+		if calls == 10 {
+			status = "Executed"
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": status}})
+	})
+	account.SetMaxPollAttempts(0)
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 5 * time.Millisecond
+
+	if _, err := account.GetTransactionOutcome("abc123", 60); err != nil {
+		t.Fatalf("Zero max attempts should poll until confirmed, got %v", err)
+	}
+	if calls != 10 {
+		t.Errorf("Expected 10 attempts, got %d", calls)
+	}
+}
+
+func TestAccount_CloseConcurrent(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {})
+	account.nonce = "5"
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			account.Close()
+		}()
+	}
+	wg.Wait()
+	account.Close()
+
+	if account.nagURL != "" || account.network != "" || account.blockchain != "" || account.nonce != "" {
+		t.Errorf("Close should reset the account, got nagURL %q, network %q, blockchain %q, nonce %q",
+			account.nagURL, account.network, account.blockchain, account.nonce)
+	}
+	if account.signingKey != nil {
+		t.Error("Close should clear the cached signing key")
+	}
+	if !account.closed {
+		t.Error("The account should be marked as closed")
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ActivitySummary aggregates the transactions of a wallet, for dashboards.
+type ActivitySummary struct {
+	Transactions int            // The number of transactions summarized.
+	Sent         int            // The number of transactions sent from the wallet.
+	Received     int            // The number of transactions sent to the wallet by others.
+	ByType       map[string]int // The number of transactions of each type, e.g. "C_TYPE_CERTIFICATE".
+	ByStatus     map[string]int // The number of transactions of each status, e.g. "Executed".
+	// FeesPaid is the total of the fees of the transactions sent from the wallet.
+	FeesPaid float64
+	// AverageConfirmation is the mean time between the timestamp of a transaction and
+	// the creation of the block it was recorded in, over the confirmed transactions.
+	// It is zero if none was confirmed.
+	AverageConfirmation time.Duration
+}
+
+// activityPageSize is the number of transactions SummarizeMyActivity fetches per
+// GetTransactionsByAddress request.
+const activityPageSize = 100
+
+// SummarizeMyActivity aggregates the transactions from position start up to, but
+// excluding, position end of the account wallet's history, as returned by
+// GetTransactionsByAddress.
+//
+// The history is fetched in pages of 100 transactions and aggregated as it arrives,
+// so a wide range is never held in memory at once. Transactions are counted by type
+// and by status, with statuses normalized as for GetTransactionOutcome, and the
+// broadcast, developer, NAG, processing and protocol fees of those the wallet sent
+// are totalled. A transaction the wallet sent to itself, such as a certificate,
+// counts as sent. The confirmation time of a transaction recorded in a block is
+// taken from its timestamp to that of the block, which costs a GetBlock lookup per
+// block. It returns an error if the account is not open or a page or block cannot
+// be fetched.
+func (a *Account) SummarizeMyActivity(start, end int) (*ActivitySummary, error) {
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+
+	summary := &ActivitySummary{ByType: make(map[string]int), ByStatus: make(map[string]int)}
+	wallet := normalizeHex(a.walletAddress)
+	var confirmed int
+	var confirmation time.Duration
+	for from := start; from < end; from += activityPageSize {
+		to := min(from+activityPageSize, end)
+		transactions, err := a.GetTransactionsByAddress(a.walletAddress, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		// The transactions of a page are mostly recorded in a few consecutive blocks.
+		blockTimes := make(map[int64]time.Time)
+		for i := range transactions {
+			tx := &transactions[i]
+			summary.Transactions++
+			summary.ByType[tx.Type]++
+			summary.ByStatus[normalizeStatus(tx.Status)]++
+			if normalizeHex(tx.From) == wallet {
+				summary.Sent++
+				summary.FeesPaid += tx.BroadcastFee + tx.DeveloperFee + tx.NagFee + tx.ProcessingFee + tx.ProtocolFee
+			} else if normalizeHex(tx.To) == wallet {
+				summary.Received++
+			}
+
+			elapsed, ok, err := a.confirmationTime(tx, blockTimes)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				confirmed++
+				confirmation += elapsed
+			}
+		}
+		if len(transactions) < to-from {
+			break
+		}
+	}
+	if confirmed > 0 {
+		summary.AverageConfirmation = confirmation / time.Duration(confirmed)
+	}
+	return summary, nil
+}
+
+// confirmationTime returns the time between the timestamp of tx and the creation of
+// the block it was recorded in, looking the block up in blockTimes before fetching
+// it. It reports false if tx is not recorded in a block or its timestamp is invalid.
+func (a *Account) confirmationTime(tx *Transaction, blockTimes map[int64]time.Time) (time.Duration, bool, error) {
+	if tx.BlockID == "" || strings.EqualFold(tx.Status, "Pending") {
+		return 0, false, nil
+	}
+	height, err := strconv.ParseInt(tx.BlockID, 10, 64)
+	if err != nil {
+		return 0, false, nil
+	}
+	submitted, err := time.Parse(timestampLayout, tx.Timestamp)
+	if err != nil {
+		return 0, false, nil
+	}
+
+	created, ok := blockTimes[height]
+	if !ok {
+		created, err = a.blockTime(height)
+		if err != nil {
+			return 0, false, err
+		}
+		blockTimes[height] = created
+	}
+	// A sender's clock running ahead of the network's cannot make confirmation
+	// take negative time.
+	return max(created.Sub(submitted), 0), true, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccount_SummarizeMyActivity(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": []map[string]interface{}{
+				{"ID": "cert1", "From": "0xtest_wallet_address", "To": "0xtest_wallet_address", "Type": "C_TYPE_CERTIFICATE", "Status": "Executed", "ProcessingFee": 1.5, "ProtocolFee": 0.5},
+				{"ID": "cert2", "From": "TEST_WALLET_ADDRESS", "To": "test_wallet_address", "Type": "C_TYPE_CERTIFICATE", "Status": "pending", "NagFee": 0.25},
+				{"ID": "send", "From": "test_wallet_address", "To": "other", "Type": "C_TYPE_COIN", "Status": "Executed", "BroadcastFee": 1, "DeveloperFee": 0.75},
+				{"ID": "incoming", "From": "other", "To": "0xtest_wallet_address", "Type": "C_TYPE_COIN", "Status": "Executed", "ProcessingFee": 100},
+			},
+		})
+	})
+
+	summary, err := account.SummarizeMyActivity(0, 10)
+	if err != nil {
+		t.Fatalf("SummarizeMyActivity failed: %v", err)
+	}
+	if summary.Transactions != 4 || summary.Sent != 3 || summary.Received != 1 {
+		t.Errorf("Expected 4 transactions, 3 sent and 1 received, got %+v", summary)
+	}
+	if summary.ByType["C_TYPE_CERTIFICATE"] != 2 || summary.ByType["C_TYPE_COIN"] != 2 {
+		t.Errorf("Unexpected counts by type %v", summary.ByType)
+	}
+	if summary.ByStatus["Executed"] != 3 || summary.ByStatus["Pending"] != 1 {
+		t.Errorf("Unexpected counts by status %v", summary.ByStatus)
+	}
+	// Fees of received transactions are paid by their sender.
+	if math.Abs(summary.FeesPaid-4) > 1e-9 {
+		t.Errorf("Expected 4 in fees paid, got %v", summary.FeesPaid)
+	}
+}
+
+func TestAccount_SummarizeMyActivityPagesAndConfirmation(t *testing.T) {
+	// 250 transactions, each recorded in block i%5, 2*(i%5) seconds after it was sent.
+	history := make([]map[string]interface{}, 250)
+	for i := range history {
+		history[i] = map[string]interface{}{
+			"ID": fmt.Sprintf("tx%d", i), "From": "other", "To": "test_wallet_address", "Type": "C_TYPE_COIN", "Status": "Executed",
+			"BlockID": strconv.Itoa(i % 5), "Timestamp": "2026:01:01-00:00:00",
+		}
+	}
+	history[249]["Status"], history[249]["BlockID"] = "Pending", ""
+
+	var pages [][2]int
+	blockLookups := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionsByAddress_"):
+			start, _ := strconv.Atoi(request["Start"].(string))
+			end, _ := strconv.Atoi(request["End"].(string))
+			pages = append(pages, [2]int{start, end})
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": history[min(start, len(history)):min(end, len(history))]})
+		case strings.Contains(r.URL.Path, "Circular_GetBlock_"):
+			blockLookups++
+			height, _ := strconv.Atoi(request["BlockNumber"].(string))
+			created := time.Date(2026, 1, 1, 0, 0, 2*height, 0, time.UTC).Format(timestampLayout)
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"Block": map[string]interface{}{"BlockNumber": height, "Timestamp": created}},
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+
+	summary, err := account.SummarizeMyActivity(0, 1000)
+	if err != nil {
+		t.Fatalf("SummarizeMyActivity failed: %v", err)
+	}
+	if summary.Transactions != 250 || summary.Received != 250 {
+		t.Errorf("Expected 250 transactions received, got %+v", summary)
+	}
+	expectedPages := [][2]int{{0, 100}, {100, 200}, {200, 300}}
+	if fmt.Sprint(pages) != fmt.Sprint(expectedPages) {
+		t.Errorf("Expected pages %v, got %v", expectedPages, pages)
+	}
+	// Each page looks up each of the 5 blocks once.
+	if blockLookups != 15 {
+		t.Errorf("Expected 15 block lookups, got %d", blockLookups)
+	}
+	// The 249 confirmed transactions are spread evenly over delays of 0 to 8 seconds,
+	// except tx249 (block 4, 8 seconds), which is pending.
+	expected := time.Duration(50*(0+2+4+6)+49*8) * time.Second / 249
+	if summary.AverageConfirmation != expected {
+		t.Errorf("Expected an average confirmation of %v, got %v", expected, summary.AverageConfirmation)
+	}
+}
+
+func TestAccount_SummarizeMyActivityNotOpen(t *testing.T) {
+	if _, err := NewAccount().SummarizeMyActivity(0, 10); err == nil {
+		t.Error("SummarizeMyActivity should fail on an account that is not open")
+	}
+}
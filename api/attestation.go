@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNoAttestation is returned by VerifyNodeAttestation when a response does not
+// carry the NodeID and Signature fields of a node attestation.
+var ErrNoAttestation = errors.New("response carries no node attestation (NodeID and Signature)")
+
+// nodeAttestation is the part of a NAG response envelope that attests to its content.
+type nodeAttestation struct {
+	Response  json.RawMessage `json:"Response"`  // The attested response, exactly as received.
+	NodeID    string          `json:"NodeID"`    // The ID of the node that signed the response.
+	Signature string          `json:"Signature"` // The DER-encoded signature of the response, in hex.
+}
+
+// VerifyNodeAttestation checks the node signature over a raw NAG response body.
+//
+// An attested response carries, next to its Response field, the NodeID of the node
+// that produced it and a hex-encoded DER signature of the SHA-256 digest of the
+// Response field's raw bytes. It reports whether that signature is valid for
+// nodePublicKey, so a tampered response (for example by a man in the middle) is
+// detected. It returns ErrNoAttestation if the response is not attested.
+func (a *Account) VerifyNodeAttestation(resp []byte, nodePublicKey string) (bool, error) {
+	var attestation nodeAttestation
+	if err := json.Unmarshal(resp, &attestation); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if attestation.NodeID == "" || attestation.Signature == "" {
+		return false, ErrNoAttestation
+	}
+
+	valid, err := verifySignature(attestation.Response, attestation.Signature, nodePublicKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid attestation: %w", err)
+	}
+	return valid, nil
+}
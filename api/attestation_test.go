@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// attestedResponse returns a NAG response envelope around response, signed by nodeKey.
+func attestedResponse(t *testing.T, response, nodeKey string) []byte {
+	t.Helper()
+	key, err := parsePrivateKey(nodeKey)
+	if err != nil {
+		t.Fatalf("invalid node key: %v", err)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"Result":    200,
+		"Response":  json.RawMessage(response),
+		"NodeID":    "node-1",
+		"Signature": hex.EncodeToString(signMessage(key, []byte(response))),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+	return body
+}
+
+func TestAccount_VerifyNodeAttestation(t *testing.T) {
+	account := NewAccount()
+	signer, _ := NewKeySigner(testPrivateKey)
+	body := attestedResponse(t, `{"Nonce":7}`, testPrivateKey)
+
+	valid, err := account.VerifyNodeAttestation(body, signer.PublicKey())
+	if err != nil {
+		t.Fatalf("VerifyNodeAttestation failed: %v", err)
+	}
+	if !valid {
+		t.Error("Attestation should verify against the node's public key")
+	}
+
+	other, _ := NewKeySigner(testSecondaryPrivateKey)
+	if valid, _ := account.VerifyNodeAttestation(body, other.PublicKey()); valid {
+		t.Error("Attestation should not verify against another key")
+	}
+
+	tampered := []byte(strings.Replace(string(body), `"Nonce":7`, `"Nonce":8`, 1))
+	if valid, _ := account.VerifyNodeAttestation(tampered, signer.PublicKey()); valid {
+		t.Error("Attestation should not verify a tampered response")
+	}
+}
+
+func TestAccount_VerifyNodeAttestationUnattested(t *testing.T) {
+	account := NewAccount()
+	signer, _ := NewKeySigner(testPrivateKey)
+
+	_, err := account.VerifyNodeAttestation([]byte(`{"Result":200,"Response":{"Nonce":7}}`), signer.PublicKey())
+	if !errors.Is(err, ErrNoAttestation) {
+		t.Errorf("Expected ErrNoAttestation, got %v", err)
+	}
+}
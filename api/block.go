@@ -0,0 +1,308 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// GetBlock retrieves the block at height blockNum of the configured blockchain.
+//
+// It returns ErrNetworkNodeNotSet if no network is configured, and an error if
+// blockNum is negative, the NAG answers with an HTTP error status, or it reports a
+// failed lookup.
+func (a *Account) GetBlock(blockNum int64) (*BlockResponse, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+	if blockNum < 0 {
+		return nil, fmt.Errorf("invalid block number %d: must not be negative", blockNum)
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain":  utils.HexFix(a.blockchain),
+		"BlockNumber": strconv.FormatInt(blockNum, 10),
+		"Version":     libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetBlock_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	var result BlockResponse
+	if err := a.decodeTyped(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get block (result %d): %s", result.Result, result.Message)
+	}
+	return &result, nil
+}
+
+// defaultMaxBlockRange is the largest number of blocks GetBlockRange fetches at once
+// when no limit has been set.
+const defaultMaxBlockRange = 1000
+
+// SetMaxBlockRange sets the largest number of blocks GetBlockRange fetches in one
+// call, to keep responses to a manageable size; zero restores the default of 1000.
+func (a *Account) SetMaxBlockRange(n int) {
+	a.maxBlockRange = n
+}
+
+// GetBlockRange retrieves the blocks at heights start to end, inclusive, of the
+// configured blockchain in a single request, in the order the NAG returns them.
+//
+// It returns an error if start is negative or greater than end, or if the range
+// spans more blocks than allowed by SetMaxBlockRange.
+func (a *Account) GetBlockRange(start, end int64) ([]Block, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+	if start < 0 || start > end {
+		return nil, fmt.Errorf("invalid block range %d-%d: start must be between 0 and end", start, end)
+	}
+	limit := int64(a.maxBlockRange)
+	if limit <= 0 {
+		limit = defaultMaxBlockRange
+	}
+	if span := end - start + 1; span > limit {
+		return nil, fmt.Errorf("invalid block range %d-%d: %d blocks exceed the maximum of %d", start, end, span, limit)
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Start":      strconv.FormatInt(start, 10),
+		"End":        strconv.FormatInt(end, 10),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetBlockRange_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get block range: %w", err)
+	}
+
+	var result struct {
+		Result   int     `json:"Result"`
+		Response []Block `json:"Response"`
+		Message  string  `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get block range (result %d): %s", result.Result, result.Message)
+	}
+	if result.Response == nil {
+		result.Response = []Block{}
+	}
+	return result.Response, nil
+}
+
+// GetTransactionInBlock retrieves the transaction at position index, counting from
+// zero, of the block at height blockNum.
+//
+// It returns an error if the block holds no transaction at that index.
+func (a *Account) GetTransactionInBlock(blockNum, index int) (*Transaction, error) {
+	block, err := a.GetBlock(int64(blockNum))
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := block.Response.Block.Transactions
+	if index < 0 || index >= len(transactions) {
+		return nil, fmt.Errorf("transaction index %d out of range: block %d holds %d transactions", index, blockNum, len(transactions))
+	}
+	return &transactions[index], nil
+}
+
+// GetBlockTransactions returns a page of the transactions of the block at height
+// blockNum, starting at offset and holding at most limit transactions, along with
+// the total number of transactions in the block.
+//
+// A page extending past the end of the block is truncated, and an offset at or
+// beyond the end yields an empty page. It returns an error if offset is negative or
+// limit is not positive.
+func (a *Account) GetBlockTransactions(blockNum, offset, limit int) ([]Transaction, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("invalid offset %d: must not be negative", offset)
+	}
+	if limit <= 0 {
+		return nil, 0, fmt.Errorf("invalid limit %d: must be positive", limit)
+	}
+
+	block, err := a.GetBlock(int64(blockNum))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	transactions := block.Response.Block.Transactions
+	total := len(transactions)
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+	}
+	return transactions[offset:end:end], total, nil
+}
+
+// GetBlockCount returns the number of blocks of the configured blockchain. Blocks
+// are numbered from zero, so the latest block is at height count-1.
+func (a *Account) GetBlockCount() (int64, error) {
+	if err := a.requireNetwork(); err != nil {
+		return 0, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetBlockCount_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return 0, fmt.Errorf("failed to get block count: %w", err)
+	}
+
+	// A failed lookup may carry a plain string in the Response field, so check the
+	// result before decoding the count.
+	var envelope struct {
+		Result   int             `json:"Result"`
+		Message  string          `json:"message"`
+		Response json.RawMessage `json:"Response"`
+	}
+	if err := a.decode(response, &envelope); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if envelope.Result != 200 {
+		return 0, fmt.Errorf("failed to get block count (result %d): %s", envelope.Result, envelope.Message)
+	}
+	var result struct {
+		Blocks json.Number `json:"Blocks"`
+	}
+	if err := a.decode(envelope.Response, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return parseBlockCount(result.Blocks)
+}
+
+// parseBlockCount converts the block count reported by the NAG to an int64. Some
+// NAGs send it as a float, such as 1234567.0 or 1.234567e6, which is accepted when it
+// holds a whole number; it is parsed exactly, so large counts are not rounded.
+func parseBlockCount(blocks json.Number) (int64, error) {
+	count, ok := new(big.Float).SetPrec(256).SetString(blocks.String())
+	if !ok {
+		return 0, fmt.Errorf("invalid block count %q", blocks)
+	}
+	if !count.IsInt() || count.Sign() < 0 {
+		return 0, fmt.Errorf("invalid block count %s: not a non-negative integer", blocks)
+	}
+	n, accuracy := count.Int64()
+	if accuracy != big.Exact {
+		return 0, fmt.Errorf("invalid block count %s: out of range", blocks)
+	}
+	return n, nil
+}
+
+// maxFindWindow is the widest time window FindTransaction searches.
+const maxFindWindow = 24 * time.Hour
+
+// FindTransaction looks up the transaction txID among the blocks created between
+// from and to, inclusive.
+//
+// The time window is translated into the start and end block parameters of
+// GetTransactionByID by binary search over the block timestamps, which costs a few
+// GetBlock lookups. It returns an error if to is before from, if the window spans
+// more than 24 hours, or if no block was created within it.
+func (a *Account) FindTransaction(txID string, from, to time.Time) (*TransactionResponse, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid time window: %s is before %s", to, from)
+	}
+	if to.Sub(from) > maxFindWindow {
+		return nil, fmt.Errorf("invalid time window: %s exceeds the maximum of %s", to.Sub(from), maxFindWindow)
+	}
+
+	count, err := a.GetBlockCount()
+	if err != nil {
+		return nil, err
+	}
+	start, err := a.firstBlockFrom(from, count)
+	if err != nil {
+		return nil, err
+	}
+	// Block timestamps have a resolution of one second.
+	end, err := a.firstBlockFrom(to.Truncate(time.Second).Add(time.Second), count)
+	if err != nil {
+		return nil, err
+	}
+	end--
+	if start > end {
+		return nil, fmt.Errorf("no block was created between %s and %s", from, to)
+	}
+
+	return a.GetTransactionByID(txID, strconv.FormatInt(start, 10), strconv.FormatInt(end, 10))
+}
+
+// firstBlockFrom returns the height of the first of the count blocks created at or
+// after t, or count if there is none.
+func (a *Account) firstBlockFrom(t time.Time, count int64) (int64, error) {
+	low, high := int64(0), count
+	for low < high {
+		mid := low + (high-low)/2
+		created, err := a.blockTime(mid)
+		if err != nil {
+			return 0, err
+		}
+		if created.Before(t) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// blockTime returns the creation time of the block at height blockNum.
+func (a *Account) blockTime(blockNum int64) (time.Time, error) {
+	block, err := a.GetBlock(blockNum)
+	if err != nil {
+		return time.Time{}, err
+	}
+	created, err := time.Parse(timestampLayout, block.Response.Block.Timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("block %d: invalid timestamp %q", blockNum, block.Response.Block.Timestamp)
+	}
+	return created, nil
+}
+
+// IsReorged re-fetches the transaction txID and reports whether it was moved out of
+// the block expectedBlockID by a chain reorganization: either it is now recorded in
+// another block, or the NAG no longer knows it. Critical flows can use it to
+// re-confirm a transaction some time after its confirmation.
+func (a *Account) IsReorged(txID, expectedBlockID string) (bool, error) {
+	if err := a.requireNetwork(); err != nil {
+		return false, err
+	}
+
+	tx, err := a.fetchTransaction(txID, "0", "10")
+	if errors.Is(err, errTransactionNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return utils.HexFix(tx.Response.BlockID) != utils.HexFix(expectedBlockID), nil
+}
@@ -0,0 +1,351 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBlockNAGAccount returns an account whose mock NAG serves a block of n
+// transactions, tx-0 to tx-<n-1>, at every height.
+func newBlockNAGAccount(t *testing.T, n int) *Account {
+	t.Helper()
+	transactions := make([]map[string]interface{}, n)
+	for i := range transactions {
+		transactions[i] = map[string]interface{}{"ID": fmt.Sprintf("tx-%d", i)}
+	}
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetBlock_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": map[string]interface{}{
+				"Block": map[string]interface{}{
+					"BlockNumber":  request["BlockNumber"],
+					"Transactions": transactions,
+				},
+			},
+		})
+	})
+}
+
+func TestAccount_GetTransactionInBlock(t *testing.T) {
+	account := newBlockNAGAccount(t, 3)
+
+	for _, index := range []int{0, 2} {
+		tx, err := account.GetTransactionInBlock(42, index)
+		if err != nil {
+			t.Fatalf("GetTransactionInBlock(42, %d) failed: %v", index, err)
+		}
+		if expected := fmt.Sprintf("tx-%d", index); tx.ID != expected {
+			t.Errorf("GetTransactionInBlock(42, %d) = %q, expected %q", index, tx.ID, expected)
+		}
+	}
+}
+
+func TestAccount_GetTransactionInBlockOutOfRange(t *testing.T) {
+	account := newBlockNAGAccount(t, 3)
+
+	for _, index := range []int{3, -1} {
+		_, err := account.GetTransactionInBlock(42, index)
+		if err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("GetTransactionInBlock(42, %d) should fail with an out of range error, got %v", index, err)
+		}
+	}
+}
+
+func TestAccount_GetBlock(t *testing.T) {
+	account := newBlockNAGAccount(t, 3)
+
+	block, err := account.GetBlock(42)
+	if err != nil {
+		t.Fatalf("GetBlock failed: %v", err)
+	}
+	if block.Response.Block.BlockNumber.String() != "42" {
+		t.Errorf("BlockNumber = %q, expected %q", block.Response.Block.BlockNumber, "42")
+	}
+
+	// Heights beyond the 32-bit range are requested exactly.
+	block, err = account.GetBlock(1 << 40)
+	if err != nil {
+		t.Fatalf("GetBlock failed: %v", err)
+	}
+	if block.Response.Block.BlockNumber.String() != "1099511627776" {
+		t.Errorf("BlockNumber = %q, expected %q", block.Response.Block.BlockNumber, "1099511627776")
+	}
+}
+
+func TestAccount_GetBlockFailures(t *testing.T) {
+	if _, err := NewAccount().GetBlock(1); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("Expected ErrNetworkNodeNotSet without a network, got %v", err)
+	}
+	if _, err := newBlockNAGAccount(t, 3).GetBlock(-1); err == nil {
+		t.Error("A negative block number should be rejected")
+	}
+
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 119, "message": "Block Not Found"})
+	})
+	if _, err := account.GetBlock(99); err == nil || !strings.Contains(err.Error(), "Block Not Found") {
+		t.Errorf("A failed lookup should be reported with the NAG message, got %v", err)
+	}
+
+	account = newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	if _, err := account.GetBlock(99); err == nil {
+		t.Error("An HTTP error status should be reported")
+	}
+}
+
+func TestAccount_GetBlockRange(t *testing.T) {
+	var request map[string]interface{}
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetBlockRange_testnet") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": []map[string]interface{}{
+				{"BlockID": "block-5", "BlockNumber": 5},
+				{"BlockID": "block-6", "BlockNumber": 6},
+			},
+		})
+	})
+
+	blocks, err := account.GetBlockRange(5, 6)
+	if err != nil {
+		t.Fatalf("GetBlockRange failed: %v", err)
+	}
+	if len(blocks) != 2 || blocks[0].BlockID != "block-5" || blocks[1].BlockID != "block-6" {
+		t.Errorf("Blocks should be returned in order, got %+v", blocks)
+	}
+	if request["Start"] != "5" || request["End"] != "6" {
+		t.Errorf("Unexpected range requested: %v", request)
+	}
+}
+
+func TestAccount_GetBlockRangeInvalid(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No request should be made, got %q", r.URL.Path)
+	})
+
+	for _, args := range [][2]int64{{6, 5}, {-1, 5}, {0, 1000}} {
+		if _, err := account.GetBlockRange(args[0], args[1]); err == nil {
+			t.Errorf("GetBlockRange(%d, %d) should fail", args[0], args[1])
+		}
+	}
+
+	account.SetMaxBlockRange(10)
+	if _, err := account.GetBlockRange(0, 10); err == nil || !strings.Contains(err.Error(), "maximum of 10") {
+		t.Errorf("Expected the configured maximum to apply, got %v", err)
+	}
+}
+
+func TestAccount_GetBlockTransactions(t *testing.T) {
+	account := newBlockNAGAccount(t, 10)
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		expected      []string
+	}{
+		{"first page", 0, 4, []string{"tx-0", "tx-1", "tx-2", "tx-3"}},
+		{"middle page", 4, 4, []string{"tx-4", "tx-5", "tx-6", "tx-7"}},
+		{"truncated last page", 8, 4, []string{"tx-8", "tx-9"}},
+		{"whole block", 0, 100, []string{"tx-0", "tx-1", "tx-2", "tx-3", "tx-4", "tx-5", "tx-6", "tx-7", "tx-8", "tx-9"}},
+		{"at end", 10, 4, []string{}},
+		{"beyond end", 25, 4, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total, err := account.GetBlockTransactions(7, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("GetBlockTransactions failed: %v", err)
+			}
+			if total != 10 {
+				t.Errorf("total = %d, expected 10", total)
+			}
+			ids := make([]string, len(page))
+			for i, tx := range page {
+				ids[i] = tx.ID
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("page = %v, expected %v", ids, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAccount_GetBlockTransactionsInvalid(t *testing.T) {
+	account := newBlockNAGAccount(t, 10)
+
+	for _, args := range [][2]int{{-1, 5}, {0, 0}, {0, -3}} {
+		if _, _, err := account.GetBlockTransactions(7, args[0], args[1]); err == nil {
+			t.Errorf("GetBlockTransactions(7, %d, %d) should fail", args[0], args[1])
+		}
+	}
+}
+
+// newTimedBlocksNAGAccount returns an account whose mock NAG holds 1000 blocks, one
+// every 10 seconds from base, and records the block range of transaction lookups.
+func newTimedBlocksNAGAccount(t *testing.T, base time.Time, lookedUp *[2]string) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetBlockCount_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Blocks": 1000}})
+		case strings.Contains(r.URL.Path, "Circular_GetBlock_"):
+			height, _ := strconv.Atoi(request["BlockNumber"].(string))
+			created := base.Add(time.Duration(height) * 10 * time.Second)
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result": 200,
+				"Response": map[string]interface{}{
+					"Block": map[string]interface{}{"BlockNumber": height, "Timestamp": created.Format(timestampLayout)},
+				},
+			})
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_"):
+			*lookedUp = [2]string{request["Start"].(string), request["End"].(string)}
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"ID": request["ID"], "Status": "Executed"},
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+}
+
+func TestAccount_FindTransaction(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var lookedUp [2]string
+	account := newTimedBlocksNAGAccount(t, base, &lookedUp)
+
+	// Blocks 31 to 60 were created between 305s and 600s after base.
+	tx, err := account.FindTransaction("abc", base.Add(305*time.Second), base.Add(600*time.Second))
+	if err != nil {
+		t.Fatalf("FindTransaction failed: %v", err)
+	}
+	if tx.Response.ID != "abc" {
+		t.Errorf("Expected transaction abc, got %q", tx.Response.ID)
+	}
+	if lookedUp != [2]string{"31", "60"} {
+		t.Errorf("Expected the block range 31-60, got %v", lookedUp)
+	}
+}
+
+func TestAccount_FindTransactionInvalidWindow(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var lookedUp [2]string
+	account := newTimedBlocksNAGAccount(t, base, &lookedUp)
+
+	if _, err := account.FindTransaction("abc", base, base.Add(25*time.Hour)); err == nil {
+		t.Error("Expected an error for a window wider than 24 hours")
+	}
+	if _, err := account.FindTransaction("abc", base, base.Add(-time.Second)); err == nil {
+		t.Error("Expected an error for a window ending before it starts")
+	}
+	if _, err := account.FindTransaction("abc", base.Add(-time.Hour), base.Add(-time.Minute)); err == nil {
+		t.Error("Expected an error for a window holding no block")
+	}
+	if lookedUp != [2]string{} {
+		t.Errorf("No transaction lookup should be made, got %v", lookedUp)
+	}
+}
+
+func TestAccount_IsReorged(t *testing.T) {
+	tests := []struct {
+		name     string
+		response interface{}
+		reorged  bool
+	}{
+		{"stable", map[string]interface{}{"ID": "abc", "BlockID": "0xblock-1", "Status": "Executed"}, false},
+		{"moved", map[string]interface{}{"ID": "abc", "BlockID": "0xblock-2", "Status": "Executed"}, true},
+		{"vanished", "Transaction Not Found", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_") {
+					t.Errorf("Unexpected endpoint %q", r.URL.Path)
+				}
+				writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": tt.response})
+			})
+
+			reorged, err := account.IsReorged("abc", "block-1")
+			if err != nil {
+				t.Fatalf("IsReorged failed: %v", err)
+			}
+			if reorged != tt.reorged {
+				t.Errorf("IsReorged = %v, expected %v", reorged, tt.reorged)
+			}
+		})
+	}
+}
+
+func TestAccount_IsReorgedLookupFailure(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := account.IsReorged("abc", "block-1"); err == nil {
+		t.Error("Expected an error when the transaction cannot be looked up")
+	}
+}
+
+func TestAccount_GetBlockCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected int64
+		fails    bool
+	}{
+		{"integer", `{"Result": 200, "Response": {"Blocks": 1234567}}`, 1234567, false},
+		{"float", `{"Result": 200, "Response": {"Blocks": 1234567.0}}`, 1234567, false},
+		{"exponent", `{"Result": 200, "Response": {"Blocks": 1.234567e6}}`, 1234567, false},
+		{"beyond float64 precision", `{"Result": 200, "Response": {"Blocks": 9007199254740993}}`, 9007199254740993, false},
+		{"fractional", `{"Result": 200, "Response": {"Blocks": 12.5}}`, 0, true},
+		{"negative", `{"Result": 200, "Response": {"Blocks": -1}}`, 0, true},
+		{"out of range", `{"Result": 200, "Response": {"Blocks": 1e30}}`, 0, true},
+		{"failed", `{"Result": 108, "Response": "Invalid Blockchain", "message": "Invalid Blockchain"}`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			})
+			count, err := account.GetBlockCount()
+			if tt.fails {
+				if err == nil {
+					t.Errorf("Expected an error, got count %d", count)
+				} else if tt.name == "failed" && !strings.Contains(err.Error(), "result 108") {
+					t.Errorf("The error should describe the failed result, got %v", err)
+				}
+				return
+			}
+			if err != nil || count != tt.expected {
+				t.Errorf("Expected %d, got %d (%v)", tt.expected, count, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// GetBlockchains lists the blockchains hosted on the configured network.
+func (a *Account) GetBlockchains() ([]BlockchainInfo, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Version": libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetBlockchains_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get blockchains: %w", err)
+	}
+
+	var result struct {
+		Result   int `json:"Result"`
+		Response struct {
+			Blockchains []BlockchainInfo `json:"Blockchains"`
+		} `json:"Response"`
+		Message string `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get blockchains (result %d): %s", result.Result, result.Message)
+	}
+	return result.Response.Blockchains, nil
+}
+
+// GetBlockchainInfo returns the metadata of chain as reported by GetBlockchains, so
+// that an opaque blockchain address can be checked against its name, network and
+// genesis details. An empty chain selects the account's configured blockchain.
+func (a *Account) GetBlockchainInfo(chain string) (*BlockchainInfo, error) {
+	if chain == "" {
+		chain = a.blockchain
+	}
+	if chain == "" {
+		return nil, fmt.Errorf("blockchain is not set: call SetBlockchain first")
+	}
+
+	blockchains, err := a.GetBlockchains()
+	if err != nil {
+		return nil, err
+	}
+	for i := range blockchains {
+		if strings.EqualFold(utils.HexFix(blockchains[i].Address), utils.HexFix(chain)) {
+			return &blockchains[i], nil
+		}
+	}
+	return nil, fmt.Errorf("blockchain %s is not hosted on %s (%d blockchains found)", chain, a.network, len(blockchains))
+}
+
+// AutoDetectBlockchain configures the blockchain on which the account's wallet exists.
+//
+// Every blockchain reported by GetBlockchains is checked for the wallet. If the
+// wallet exists on exactly one of them, that blockchain is set as with SetBlockchain;
+// otherwise an error is returned and the configured blockchain is left unchanged.
+func (a *Account) AutoDetectBlockchain() error {
+	if a.walletAddress == "" {
+		return fmt.Errorf("account is not open")
+	}
+	blockchains, err := a.GetBlockchains()
+	if err != nil {
+		return err
+	}
+
+	var found []string
+	for _, blockchain := range blockchains {
+		exists, err := a.checkWallet(blockchain.Address, a.walletAddress)
+		if err != nil && !errors.Is(err, ErrWalletNotFound) {
+			return err
+		}
+		if exists {
+			found = append(found, blockchain.Address)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("wallet %s was not found on any of the %d blockchains", a.walletAddress, len(blockchains))
+	case 1:
+		a.SetBlockchain(found[0])
+		return nil
+	default:
+		return fmt.Errorf("wallet %s exists on %d blockchains, call SetBlockchain to choose one: %v", a.walletAddress, len(found), found)
+	}
+}
+
+// WithBlockchain returns a copy of the account scoped to chain, so queries and
+// submissions can target several blockchains without changing the account's own.
+//
+// The copy shares the HTTP client and network configuration of the account and
+// carries over its settings. Since nonces are kept per blockchain, the copy starts
+// without a nonce or nonce manager; call UpdateAccount on it before submitting.
+// Pending submissions, the deduplication cache and the shutdown state are not shared,
+// and a cached signing key is copied, so closing either account does not wipe the
+// other's key.
+func (a *Account) WithBlockchain(chain string) *Account {
+	scoped := &Account{accountSettings: a.accountSettings}
+	scoped.blockchain = chain
+	if a.signingKey != nil {
+		keyBytes := a.signingKey.Key.Bytes()
+		scoped.signingKey, _ = btcec.PrivKeyFromBytes(keyBytes[:])
+		wipeBytes(keyBytes[:])
+	}
+	return scoped
+}
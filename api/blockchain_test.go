@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newBlockchainsNAGAccount returns an account whose mock NAG hosts chain-a, chain-b
+// and chain-c, with the wallet existing on the chains listed in walletChains.
+func newBlockchainsNAGAccount(t *testing.T, walletChains ...string) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetBlockchains_"):
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result": 200,
+				"Response": map[string]interface{}{
+					"Blockchains": []map[string]interface{}{
+						{"Address": "chain-a", "Name": "A"},
+						{"Address": "chain-b", "Name": "B", "Network": "testnet", "Genesis": map[string]interface{}{"Timestamp": "2024:01:01-00:00:00"}},
+						{"Address": "chain-c", "Name": "C"},
+					},
+				},
+			})
+		case strings.Contains(r.URL.Path, "Circular_CheckWallet_"):
+			var request map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			for _, chain := range walletChains {
+				if request["Blockchain"] == chain {
+					writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": true})
+					return
+				}
+			}
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 118, "Response": "Wallet Not Found"})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+}
+
+func TestAccount_AutoDetectBlockchain(t *testing.T) {
+	account := newBlockchainsNAGAccount(t, "chain-b")
+
+	if err := account.AutoDetectBlockchain(); err != nil {
+		t.Fatalf("AutoDetectBlockchain failed: %v", err)
+	}
+	if account.blockchain != "chain-b" {
+		t.Errorf("blockchain = %q, expected %q", account.blockchain, "chain-b")
+	}
+}
+
+func TestAccount_AutoDetectBlockchainNotFound(t *testing.T) {
+	account := newBlockchainsNAGAccount(t)
+
+	err := account.AutoDetectBlockchain()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if account.blockchain != "0xtest_blockchain" {
+		t.Errorf("The blockchain should be left unchanged, got %q", account.blockchain)
+	}
+}
+
+func TestAccount_AutoDetectBlockchainAmbiguous(t *testing.T) {
+	account := newBlockchainsNAGAccount(t, "chain-a", "chain-c")
+
+	err := account.AutoDetectBlockchain()
+	if err == nil || !strings.Contains(err.Error(), "2 blockchains") {
+		t.Errorf("Expected an ambiguity error, got %v", err)
+	}
+	if account.blockchain != "0xtest_blockchain" {
+		t.Errorf("The blockchain should be left unchanged, got %q", account.blockchain)
+	}
+}
+
+func TestAccount_GetBlockchainInfo(t *testing.T) {
+	account := newBlockchainsNAGAccount(t)
+
+	info, err := account.GetBlockchainInfo("chain-b")
+	if err != nil {
+		t.Fatalf("GetBlockchainInfo failed: %v", err)
+	}
+	if info.Address != "chain-b" || info.Name != "B" || info.Network != "testnet" {
+		t.Errorf("Unexpected blockchain info %+v", info)
+	}
+	if info.Genesis["Timestamp"] != "2024:01:01-00:00:00" {
+		t.Errorf("Genesis details should be reported, got %v", info.Genesis)
+	}
+
+	account.SetBlockchain("chain-c")
+	info, err = account.GetBlockchainInfo("")
+	if err != nil || info.Name != "C" {
+		t.Errorf("Empty chain should select the configured blockchain, got %+v (%v)", info, err)
+	}
+}
+
+func TestAccount_GetBlockchainInfoUnknown(t *testing.T) {
+	account := newBlockchainsNAGAccount(t)
+
+	info, err := account.GetBlockchainInfo("chain-z")
+	if err == nil || !strings.Contains(err.Error(), "chain-z") {
+		t.Errorf("Expected an error naming the unknown chain, got %+v (%v)", info, err)
+	}
+}
+
+func TestAccount_WithBlockchain(t *testing.T) {
+	var chains []string
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		chains = append(chains, payload["Blockchain"].(string))
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": 3}})
+	})
+	account.nonce = "7"
+
+	other := account.WithBlockchain("0xother_chain")
+	if other.client != account.client || other.network != account.network {
+		t.Error("The clone should share the client and network configuration")
+	}
+	if other.nonce != "" {
+		t.Errorf("The clone should start without a nonce, got %q", other.nonce)
+	}
+
+	if _, err := other.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount on the clone failed: %v", err)
+	}
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	if len(chains) != 2 || chains[0] != "other_chain" || chains[1] != "test_blockchain" {
+		t.Errorf("Expected queries to other_chain then test_blockchain, got %v", chains)
+	}
+	if account.blockchain != "0xtest_blockchain" {
+		t.Errorf("The original account should be unchanged, got blockchain %q", account.blockchain)
+	}
+}
+
+func TestAccount_WithBlockchainSigningKey(t *testing.T) {
+	account := NewAccount()
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+	account.SetMaxBlockRange(50)
+
+	other := account.WithBlockchain("0xother_chain")
+	if other.maxBlockRange != 50 {
+		t.Errorf("The clone should carry over the settings, got a block range of %d", other.maxBlockRange)
+	}
+	account.Close()
+
+	signature, err := other.SignData([]byte("still usable"), "")
+	if err != nil {
+		t.Fatalf("SignData failed: %v", err)
+	}
+	signer, _ := NewKeySigner(testPrivateKey)
+	if valid, err := verifySignature([]byte("still usable"), hex.EncodeToString(signature), signer.PublicKey()); err != nil || !valid {
+		t.Errorf("Closing the original should not wipe the clone's key, got %v, %v", valid, err)
+	}
+}
@@ -1,6 +1,10 @@
 package api
 
-import "encoding/json"
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
 
 // Certificate represents a data certificate that can be certified on the blockchain.
 // It encapsulates the data content and provides utility methods for certificate operations.
@@ -8,6 +12,43 @@ type Certificate struct {
 	// data holds the raw content of the certificate. This field is unexported as its
 	// access is controlled via SetData and GetData methods.
 	data []byte
+	// previousTxID is the ID of the transaction of the previous certificate in a chain
+	// of certificates, if any.
+	previousTxID string
+	// previousBlock is the block holding the previous certificate in a chain, if any.
+	previousBlock string
+	// version is the protocol version the certificate targets; empty means the
+	// library version.
+	version string
+}
+
+// knownCertificateVersions lists the protocol versions certificates can target.
+var knownCertificateVersions = []string{"1.0.0", "1.0.1"}
+
+// FieldCasing selects the naming convention of the fields of a JSON certificate.
+type FieldCasing int
+
+const (
+	// CamelCase names fields like "previousTxID", the default.
+	CamelCase FieldCasing = iota
+	// SnakeCase names fields like "previous_tx_id", as some NAG deployments expect.
+	SnakeCase
+)
+
+// certificateJSON is the camelCase JSON form of a certificate.
+type certificateJSON struct {
+	Data          string `json:"data"`
+	PreviousTxID  string `json:"previousTxID,omitempty"`
+	PreviousBlock string `json:"previousBlock,omitempty"`
+	Version       string `json:"version"`
+}
+
+// certificateJSONSnake is the snake_case JSON form of a certificate.
+type certificateJSONSnake struct {
+	Data          string `json:"data"`
+	PreviousTxID  string `json:"previous_tx_id,omitempty"`
+	PreviousBlock string `json:"previous_block,omitempty"`
+	Version       string `json:"version"`
 }
 
 // SetData sets the data content of the certificate.
@@ -25,20 +66,108 @@ func (c *Certificate) GetData() []byte {
 	return c.data
 }
 
+// SetPreviousTxID links the certificate to the transaction of a previous certificate.
+func (c *Certificate) SetPreviousTxID(txID string) {
+	c.previousTxID = txID
+}
+
+// GetPreviousTxID returns the ID of the transaction of the previous certificate.
+func (c *Certificate) GetPreviousTxID() string {
+	return c.previousTxID
+}
+
+// SetPreviousBlock links the certificate to the block holding a previous certificate.
+func (c *Certificate) SetPreviousBlock(block string) {
+	c.previousBlock = block
+}
+
+// GetPreviousBlock returns the block holding the previous certificate.
+func (c *Certificate) GetPreviousBlock() string {
+	return c.previousBlock
+}
+
+// SetVersion sets the protocol version the certificate targets, which defaults to
+// the library version returned by Version. It returns an error, leaving the version
+// unchanged, if v is not a known protocol version.
+func (c *Certificate) SetVersion(v string) error {
+	for _, known := range knownCertificateVersions {
+		if v == known {
+			c.version = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown certificate version %q, expected one of %v", v, knownCertificateVersions)
+}
+
+// GetVersion returns the protocol version the certificate targets.
+func (c *Certificate) GetVersion() string {
+	if c.version == "" {
+		return libVersion
+	}
+	return c.version
+}
+
 // GetJSONCertificate returns the certificate's data as a JSON string.
 //
 // This method serializes the internal data content of the certificate
-// into a JSON formatted string.
+// into a JSON formatted string, with camelCase field names.
 // A more robust implementation would handle potential JSON marshaling errors.
 func (c *Certificate) GetJSONCertificate() string {
-	// Wrap the data in a JSON object
-	jsonString, err := json.Marshal(map[string]interface{}{"data": string(c.data)})
+	return c.GetJSONCertificateAs(CamelCase)
+}
+
+// GetJSONCertificateAs returns the certificate as a JSON string whose field names
+// follow the given casing. The previous certificate fields are omitted when unset;
+// the version is always included.
+func (c *Certificate) GetJSONCertificateAs(casing FieldCasing) string {
+	fields := certificateJSON{
+		Data:          string(c.data),
+		PreviousTxID:  c.previousTxID,
+		PreviousBlock: c.previousBlock,
+		Version:       c.GetVersion(),
+	}
+
+	var jsonString []byte
+	var err error
+	if casing == SnakeCase {
+		jsonString, err = json.Marshal(certificateJSONSnake(fields))
+	} else {
+		jsonString, err = json.Marshal(fields)
+	}
 	if err != nil {
 		return "{}" // Return empty JSON on error
 	}
 	return string(jsonString)
 }
 
+// GetJSONCertificateSize returns the size in bytes of the certificate's JSON form
+// with the given casing, as returned by GetJSONCertificateAs.
+func (c *Certificate) GetJSONCertificateSize(casing FieldCasing) int {
+	return len(c.GetJSONCertificateAs(casing))
+}
+
+// Sign produces a detached signature of the certificate, so it can be exchanged and
+// verified offline before, or independently of, being anchored on the blockchain.
+//
+// The signed message is the canonical JSON form returned by GetJSONCertificate, hashed
+// with SHA-256 and signed with ECDSA over secp256k1 using the hex-encoded privateKey.
+// It returns the hex-encoded DER signature.
+func (c *Certificate) Sign(privateKey string) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	return hex.EncodeToString(signMessage(key, []byte(c.GetJSONCertificate()))), nil
+}
+
+// VerifySignature reports whether signatureHex, as returned by Sign, is a valid
+// signature of the certificate by the hex-encoded publicKey. Any change to the
+// certificate since it was signed makes the verification fail.
+func (c *Certificate) VerifySignature(signatureHex, publicKey string) (bool, error) {
+	return verifySignature([]byte(c.GetJSONCertificate()), signatureHex, publicKey)
+}
+
 // GetCertificateSize returns the size of the certificate in bytes.
 //
 // This method typically calculates the size of the certificate's
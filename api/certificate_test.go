@@ -119,4 +119,120 @@ func TestCertificate_SetGetDataRoundTrip(t *testing.T) {
 			t.Errorf("SetData/GetData round trip failed: original %v, retrieved %v", original, retrieved)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCertificate_GetJSONCertificateAs(t *testing.T) {
+	cert := &Certificate{}
+	cert.SetData([]byte("hello"))
+	cert.SetPreviousTxID("abc123")
+	cert.SetPreviousBlock("42")
+
+	tests := []struct {
+		casing   FieldCasing
+		expected string
+	}{
+		{CamelCase, `{"data":"hello","previousTxID":"abc123","previousBlock":"42","version":"1.0.1"}`},
+		{SnakeCase, `{"data":"hello","previous_tx_id":"abc123","previous_block":"42","version":"1.0.1"}`},
+	}
+
+	for _, tt := range tests {
+		if result := cert.GetJSONCertificateAs(tt.casing); result != tt.expected {
+			t.Errorf("GetJSONCertificateAs(%d): expected %s, got %s", tt.casing, tt.expected, result)
+		}
+		if size := cert.GetJSONCertificateSize(tt.casing); size != len(tt.expected) {
+			t.Errorf("GetJSONCertificateSize(%d): expected %d, got %d", tt.casing, len(tt.expected), size)
+		}
+	}
+
+	if cert.GetJSONCertificate() != cert.GetJSONCertificateAs(CamelCase) {
+		t.Error("GetJSONCertificate should default to camelCase")
+	}
+}
+
+func TestCertificate_GetJSONCertificateWithoutLinks(t *testing.T) {
+	cert := &Certificate{data: []byte("hello")}
+
+	if result := cert.GetJSONCertificateAs(SnakeCase); result != `{"data":"hello","version":"1.0.1"}` {
+		t.Errorf("Unset previous certificate fields should be omitted, got %s", result)
+	}
+}
+
+func TestCertificate_SignAndVerify(t *testing.T) {
+	cert := &Certificate{}
+	cert.SetData([]byte("contract v1"))
+	signer, _ := NewKeySigner(testPrivateKey)
+
+	signature, err := cert.Sign(testPrivateKey)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	valid, err := cert.VerifySignature(signature, signer.PublicKey())
+	if err != nil {
+		t.Fatalf("VerifySignature failed: %v", err)
+	}
+	if !valid {
+		t.Error("The signature should verify against the signer's public key")
+	}
+
+	other, _ := NewKeySigner(testSecondaryPrivateKey)
+	if valid, _ := cert.VerifySignature(signature, other.PublicKey()); valid {
+		t.Error("The signature should not verify against another public key")
+	}
+}
+
+func TestCertificate_VerifySignatureTampered(t *testing.T) {
+	cert := &Certificate{}
+	cert.SetData([]byte("contract v1"))
+	signer, _ := NewKeySigner(testPrivateKey)
+	signature, _ := cert.Sign(testPrivateKey)
+
+	cert.SetData([]byte("contract v2"))
+	if valid, _ := cert.VerifySignature(signature, signer.PublicKey()); valid {
+		t.Error("Changing the data should invalidate the signature")
+	}
+
+	cert.SetData([]byte("contract v1"))
+	cert.SetPreviousTxID("abc123")
+	if valid, _ := cert.VerifySignature(signature, signer.PublicKey()); valid {
+		t.Error("Changing the previous transaction should invalidate the signature")
+	}
+}
+
+func TestCertificate_SignInvalidKey(t *testing.T) {
+	cert := &Certificate{data: []byte("data")}
+
+	if _, err := cert.Sign("not_a_hex_key"); err == nil {
+		t.Error("Sign should fail for a non-hex private key")
+	}
+}
+
+func TestCertificate_SetVersion(t *testing.T) {
+	cert := &Certificate{data: []byte("hello")}
+	defaultSize := cert.GetJSONCertificateSize(CamelCase)
+
+	if err := cert.SetVersion("1.0.0"); err != nil {
+		t.Fatalf("SetVersion failed: %v", err)
+	}
+
+	if result := cert.GetJSONCertificate(); result != `{"data":"hello","version":"1.0.0"}` {
+		t.Errorf("GetJSONCertificate should carry the custom version, got %s", result)
+	}
+	if cert.GetVersion() != "1.0.0" {
+		t.Errorf("GetVersion: expected %q, got %q", "1.0.0", cert.GetVersion())
+	}
+	if size := cert.GetJSONCertificateSize(CamelCase); size != defaultSize {
+		t.Errorf("GetJSONCertificateSize: expected %d, got %d", defaultSize, size)
+	}
+}
+
+func TestCertificate_SetVersionInvalid(t *testing.T) {
+	cert := &Certificate{}
+
+	if err := cert.SetVersion("9.9.9"); err == nil {
+		t.Error("SetVersion should reject an unknown version")
+	}
+	if cert.GetVersion() != Version() {
+		t.Errorf("An invalid version should leave the default in place, got %q", cert.GetVersion())
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// CheckClockSkew compares the local clock with the time reported by the NAG in its
+// analytics and returns the skew: positive when the local clock is behind the NAG,
+// negative when it is ahead. Transactions stamped by a badly skewed clock may be
+// rejected.
+//
+// The NAG time has a resolution of one second and is compared with the midpoint of
+// the request, so the skew is only accurate to about a second plus half the round
+// trip. When clock correction is enabled, the skew is also applied to the
+// timestamps of subsequent transactions. It returns an error if the NAG does not
+// report its time.
+func (a *Account) CheckClockSkew() (time.Duration, error) {
+	sent := time.Now()
+	analytics, err := a.GetAnalytics()
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+
+	reported := analytics.Response.Timestamp
+	if reported == "" {
+		return 0, fmt.Errorf("the NAG does not report its time")
+	}
+	nagTime, err := time.Parse(timestampLayout, reported)
+	if err != nil {
+		return 0, fmt.Errorf("invalid NAG time %q: expected the YYYY:MM:DD-HH:MM:SS layout", reported)
+	}
+	skew := nagTime.Sub(sent.Add(received.Sub(sent) / 2)).Round(time.Second)
+
+	if a.clockCorrection {
+		a.submitMu.Lock()
+		a.clockOffset = skew
+		a.submitMu.Unlock()
+	}
+	return skew, nil
+}
+
+// SetClockCorrection makes CheckClockSkew correct the timestamps of transactions
+// built afterwards by the measured skew, so submissions from a host with a skewed
+// clock carry the NAG's time. Disabling it discards the correction.
+func (a *Account) SetClockCorrection(enabled bool) {
+	a.submitMu.Lock()
+	defer a.submitMu.Unlock()
+	a.clockCorrection = enabled
+	if !enabled {
+		a.clockOffset = 0
+	}
+}
+
+// transactionTimestamp returns the timestamp of a new transaction, corrected by the
+// clock offset measured by CheckClockSkew, if any.
+func (a *Account) transactionTimestamp() string {
+	ts := timestamp()
+	if a.clockOffset == 0 {
+		return ts
+	}
+	local, err := time.Parse(timestampLayout, ts)
+	if err != nil {
+		return ts
+	}
+	return local.Add(a.clockOffset).Format(timestampLayout)
+}
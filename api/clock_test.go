@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newClockNAGAccount returns an account whose mock NAG reports a time offset by skew
+// from the local clock, or none if skew is nil, and records submitted transactions.
+func newClockNAGAccount(t *testing.T, skew *time.Duration, submitted *[]SignedTransaction) *Account {
+	t.Helper()
+	account := newRecordingNAGAccount(t, submitted, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetAnalytics_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+			return
+		}
+		response := map[string]interface{}{"BlockTime": 5}
+		if skew != nil {
+			response["Timestamp"] = time.Now().UTC().Add(*skew).Format(timestampLayout)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": response})
+	})
+	account.nonce = "1"
+	return account
+}
+
+// closeTo reports whether got is within two seconds of expected: NAG and transaction
+// timestamps both have a resolution of one second.
+func closeTo(got, expected time.Duration) bool {
+	diff := got - expected
+	return diff <= 2*time.Second && diff >= -2*time.Second
+}
+
+func TestAccount_CheckClockSkew(t *testing.T) {
+	skew := 90 * time.Second
+	var submitted []SignedTransaction
+	account := newClockNAGAccount(t, &skew, &submitted)
+
+	measured, err := account.CheckClockSkew()
+	if err != nil {
+		t.Fatalf("CheckClockSkew failed: %v", err)
+	}
+	if !closeTo(measured, skew) {
+		t.Errorf("CheckClockSkew = %s, expected about %s", measured, skew)
+	}
+
+	// Without correction, transactions keep the local time.
+	if _, err := account.SubmitCertificate([]byte("uncorrected"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	stamped, err := time.Parse(timestampLayout, submitted[0].Timestamp)
+	if err != nil {
+		t.Fatalf("invalid timestamp %q", submitted[0].Timestamp)
+	}
+	if !closeTo(stamped.Sub(time.Now().UTC()), 0) {
+		t.Errorf("The timestamp should not be corrected by default, got %s", submitted[0].Timestamp)
+	}
+}
+
+func TestAccount_SetClockCorrection(t *testing.T) {
+	skew := -2 * time.Minute
+	var submitted []SignedTransaction
+	account := newClockNAGAccount(t, &skew, &submitted)
+	account.SetClockCorrection(true)
+
+	if _, err := account.CheckClockSkew(); err != nil {
+		t.Fatalf("CheckClockSkew failed: %v", err)
+	}
+	if _, err := account.SubmitCertificate([]byte("corrected"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	stamped, err := time.Parse(timestampLayout, submitted[0].Timestamp)
+	if err != nil {
+		t.Fatalf("invalid timestamp %q", submitted[0].Timestamp)
+	}
+	if offset := stamped.Sub(time.Now().UTC()); !closeTo(offset, skew) {
+		t.Errorf("The timestamp should carry the NAG time, got an offset of %s", offset)
+	}
+
+	account.SetClockCorrection(false)
+	if account.clockOffset != 0 {
+		t.Errorf("Disabling correction should discard the offset, got %s", account.clockOffset)
+	}
+}
+
+func TestAccount_CheckClockSkewUnreported(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newClockNAGAccount(t, nil, &submitted)
+	if _, err := account.CheckClockSkew(); err == nil {
+		t.Error("CheckClockSkew should fail when the NAG does not report its time")
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Codec encodes NAG requests and decodes NAG responses in a wire format. Its media
+// type is negotiated with the NAG through the Content-Type and Accept headers.
+type Codec interface {
+	ContentType() string                        // The media type of encoded bodies.
+	Marshal(v interface{}) ([]byte, error)      // Encodes a request payload.
+	Unmarshal(data []byte, v interface{}) error // Decodes a response body into v.
+}
+
+// ErrCodecNotImplemented is returned by codecs whose wire format is not supported yet.
+var ErrCodecNotImplemented = errors.New("codec not implemented")
+
+// JSONCodec is the default codec. It decodes numbers as described for the typed
+// responses, so large integers keep their precision.
+type JSONCodec struct{}
+
+// ContentType returns "application/json".
+func (JSONCodec) ContentType() string { return "application/json" }
+
+// Marshal encodes v as JSON.
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal decodes a JSON body into v.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error { return decodeResponse(data, v) }
+
+// MessagePackCodec negotiates the MessagePack format with NAGs that support it. It is
+// a placeholder: encoding and decoding fail with ErrCodecNotImplemented.
+type MessagePackCodec struct{}
+
+// ContentType returns "application/msgpack".
+func (MessagePackCodec) ContentType() string { return "application/msgpack" }
+
+// Marshal returns ErrCodecNotImplemented.
+func (MessagePackCodec) Marshal(v interface{}) ([]byte, error) {
+	return nil, ErrCodecNotImplemented
+}
+
+// Unmarshal returns ErrCodecNotImplemented.
+func (MessagePackCodec) Unmarshal(data []byte, v interface{}) error {
+	return ErrCodecNotImplemented
+}
+
+// SetCodec sets the codec used to exchange requests and responses with the NAG. A
+// nil codec restores the default, JSONCodec. SetStrictDecoding only applies to JSON.
+func (a *Account) SetCodec(c Codec) {
+	a.codec = c
+	if a.client != nil {
+		a.client.SetCodec(c)
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// jsonAsMessagePackCodec sends JSON under the MessagePack media type, to observe the
+// headers negotiated for MessagePack without a real encoder.
+type jsonAsMessagePackCodec struct{ JSONCodec }
+
+func (jsonAsMessagePackCodec) ContentType() string { return MessagePackCodec{}.ContentType() }
+
+// newCodecNAGAccount returns an account whose mock NAG records the negotiated headers
+// and replies to wallet lookups in the media type it was asked for.
+func newCodecNAGAccount(t *testing.T, contentType, accept *string) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		*contentType, *accept = r.Header.Get("Content-Type"), r.Header.Get("Accept")
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+		w.Write([]byte(`{"Result": 200, "Response": {"Address": "0xtest_wallet_address", "Nonce": 7}}`))
+	})
+}
+
+func TestAccount_SetCodecJSON(t *testing.T) {
+	var contentType, accept string
+	account := newCodecNAGAccount(t, &contentType, &accept)
+
+	for _, codec := range []Codec{nil, JSONCodec{}} {
+		account.SetCodec(codec)
+		wallet, err := account.GetWallet()
+		if err != nil {
+			t.Fatalf("GetWallet failed: %v", err)
+		}
+		if wallet.Response.Nonce != 7 {
+			t.Errorf("Expected nonce 7, got %d", wallet.Response.Nonce)
+		}
+		if contentType != "application/json" || accept != "application/json" {
+			t.Errorf("Expected JSON headers, got Content-Type %q and Accept %q", contentType, accept)
+		}
+	}
+}
+
+func TestAccount_SetCodecNegotiatesMediaType(t *testing.T) {
+	var contentType, accept string
+	account := newCodecNAGAccount(t, &contentType, &accept)
+	account.SetCodec(jsonAsMessagePackCodec{})
+
+	wallet, err := account.GetWallet()
+	if err != nil {
+		t.Fatalf("GetWallet failed: %v", err)
+	}
+	if wallet.Response.Nonce != 7 {
+		t.Errorf("Expected the codec to decode the response, got nonce %d", wallet.Response.Nonce)
+	}
+	if contentType != "application/msgpack" || accept != "application/msgpack" {
+		t.Errorf("Expected MessagePack headers, got Content-Type %q and Accept %q", contentType, accept)
+	}
+}
+
+func TestAccount_SetCodecMessagePackStub(t *testing.T) {
+	var contentType, accept string
+	account := newCodecNAGAccount(t, &contentType, &accept)
+	account.SetCodec(MessagePackCodec{})
+
+	if _, err := account.GetWallet(); !errors.Is(err, ErrCodecNotImplemented) {
+		t.Errorf("Expected ErrCodecNotImplemented, got %v", err)
+	}
+	if contentType != "" {
+		t.Error("No request should be sent when the payload cannot be encoded")
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// minSaltLength is the minimum length, in bytes, of the salt of a hashed certificate.
+// Shorter salts would let guessable data be recovered from its commitment.
+const minSaltLength = 16
+
+// SubmitCertificateHashed anchors a salted hash of data instead of data itself, for
+// data that must not be disclosed on-chain. It returns the ID of the transaction and
+// the commitment, the hex-encoded SHA-256 of salt followed by data, which is the
+// certificate's on-chain data. Revealing data and salt later proves the data was
+// anchored.
+//
+// It returns ValidationErrors if salt is shorter than 16 bytes or the private key is
+// invalid, and an error if the NAG rejected the certificate.
+func (a *Account) SubmitCertificateHashed(data, salt []byte, privateKey string) (txID, commitment string, err error) {
+	digest := saltedHash(data, salt)
+	errs := a.validateSubmission(digest, privateKey)
+	if len(salt) < minSaltLength {
+		errs = append(errs, ValidationError{Field: "salt", Reason: fmt.Sprintf("must be at least %d bytes", minSaltLength)})
+	}
+	if err := errs.asError(); err != nil {
+		return "", "", err
+	}
+
+	if err := a.beginSubmission(); err != nil {
+		return "", "", err
+	}
+	defer a.endSubmission()
+
+	tx, _, err := a.submitPayload(newCertificatePayload(digest), privateKey)
+	if err != nil {
+		return "", "", err
+	}
+	return tx.ID, hex.EncodeToString(digest), nil
+}
+
+// VerifyReveal reports whether data and salt, revealed by the submitter of a hashed
+// certificate, match commitment, the hex-encoded certificate data found on-chain or
+// returned by SubmitCertificateHashed.
+//
+// The commitment is the SHA-256 of salt followed by data, in that order; a reveal
+// with data and salt swapped does not match. The comparison is case-insensitive and
+// tolerates a "0x" prefix on commitment.
+func VerifyReveal(data, salt []byte, commitment string) bool {
+	expected, err := hex.DecodeString(utils.HexFix(commitment))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(saltedHash(data, salt), expected) == 1
+}
+
+// saltedHash returns the SHA-256 of salt followed by data.
+func saltedHash(data, salt []byte) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSalt is a 16-byte salt for hashed certificates.
+var testSalt = []byte("0123456789abcdef")
+
+func TestAccount_SubmitCertificateHashed(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	data := []byte("confidential contract")
+
+	txID, commitment, err := account.SubmitCertificateHashed(data, testSalt, testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateHashed failed: %v", err)
+	}
+	if txID != submitted[0].ID {
+		t.Errorf("Expected transaction ID %s, got %s", submitted[0].ID, txID)
+	}
+
+	expected := sha256.Sum256(append(append([]byte{}, testSalt...), data...))
+	if commitment != hex.EncodeToString(expected[:]) {
+		t.Errorf("Commitment %s should be SHA-256(salt || data)", commitment)
+	}
+	if onChain := hex.EncodeToString([]byte(certificateData(t, submitted[0].Payload))); onChain != commitment {
+		t.Errorf("The certificate should hold the commitment, got %s", onChain)
+	}
+}
+
+func TestAccount_SubmitCertificateHashedSalts(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	data := []byte("confidential contract")
+
+	_, first, err := account.SubmitCertificateHashed(data, testSalt, testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateHashed failed: %v", err)
+	}
+	_, second, err := account.SubmitCertificateHashed(data, []byte("fedcba9876543210"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateHashed failed: %v", err)
+	}
+	if first == second {
+		t.Error("Different salts should yield different commitments")
+	}
+
+	_, _, err = account.SubmitCertificateHashed(data, []byte("short"), testPrivateKey)
+	if fields := validationFields(t, err); len(fields) != 1 || fields[0][0] != "salt" {
+		t.Errorf("Expected a salt validation error, got %v", fields)
+	}
+
+	_, _, err = account.SubmitCertificateHashed(data, []byte("short"), "not-hex")
+	expected := [][2]string{{"privateKey", "must be hexadecimal"}, {"salt", "must be at least 16 bytes"}}
+	if fields := validationFields(t, err); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+	if len(submitted) != 2 {
+		t.Errorf("A rejected submission should not be sent, got %d submissions", len(submitted))
+	}
+}
+
+func TestVerifyReveal(t *testing.T) {
+	data := []byte("confidential contract")
+	digest := sha256.Sum256(append(append([]byte{}, testSalt...), data...))
+	commitment := hex.EncodeToString(digest[:])
+
+	tests := []struct {
+		name       string
+		data, salt []byte
+		commitment string
+		expected   bool
+	}{
+		{"correct reveal", data, testSalt, commitment, true},
+		{"prefixed upper-case commitment", data, testSalt, "0x" + strings.ToUpper(commitment), true},
+		{"wrong data", []byte("forged contract"), testSalt, commitment, false},
+		{"wrong salt", data, []byte("fedcba9876543210"), commitment, false},
+		{"salt and data swapped", testSalt, data, commitment, false},
+		{"malformed commitment", data, testSalt, "not hex", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyReveal(tt.data, tt.salt, tt.commitment); got != tt.expected {
+				t.Errorf("VerifyReveal = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// Equal reports whether t and other describe the same transaction.
+//
+// Only the semantically significant fields are compared: ID, Payload, Nonce, From,
+// To and OSignature. Hexadecimal values are compared without "0x" prefixes and
+// case-insensitively. Node-specific metadata (NodeID, BlockID, Status, Timestamp)
+// and fees are ignored.
+func (t *Transaction) Equal(other *Transaction) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return normalizeHex(t.ID) == normalizeHex(other.ID) &&
+		normalizeHex(t.Payload) == normalizeHex(other.Payload) &&
+		strings.TrimSpace(t.Nonce) == strings.TrimSpace(other.Nonce) &&
+		normalizeHex(t.From) == normalizeHex(other.From) &&
+		normalizeHex(t.To) == normalizeHex(other.To) &&
+		normalizeHex(t.OSignature) == normalizeHex(other.OSignature)
+}
+
+// TransactionsEqual is the map-based variant of Transaction.Equal, for transactions
+// decoded into map[string]interface{}. The signature is read from "OSignature",
+// or "Signature" if absent, and numeric nonces are compared by value regardless
+// of whether they were decoded as float64, json.Number or string.
+func TransactionsEqual(a, b map[string]interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	for _, field := range []string{"ID", "Payload", "From", "To"} {
+		if normalizeHex(mapString(a, field)) != normalizeHex(mapString(b, field)) {
+			return false
+		}
+	}
+	return mapString(a, "Nonce") == mapString(b, "Nonce") &&
+		normalizeHex(mapSignature(a)) == normalizeHex(mapSignature(b))
+}
+
+// normalizeHex strips the "0x" prefix and lowercases a hexadecimal string.
+func normalizeHex(s string) string {
+	return strings.ToLower(utils.HexFix(strings.TrimSpace(s)))
+}
+
+// mapSignature returns the transaction signature stored in m.
+func mapSignature(m map[string]interface{}) string {
+	if _, ok := m["OSignature"]; ok {
+		return mapString(m, "OSignature")
+	}
+	return mapString(m, "Signature")
+}
+
+// mapString returns m[key] as a string, formatting numbers without exponent or
+// trailing zeros. A missing key yields the empty string.
+func mapString(m map[string]interface{}, key string) string {
+	switch v := m[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
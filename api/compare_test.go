@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func baseTransactionMap() map[string]interface{} {
+	return map[string]interface{}{
+		"ID":         "abc123",
+		"Payload":    "7b7d",
+		"Nonce":      float64(42),
+		"From":       "0xsender",
+		"To":         "recipient",
+		"OSignature": "3045",
+		"NodeID":     "node_1",
+		"NagFee":     0.5,
+	}
+}
+
+func TestTransactionsEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m map[string]interface{})
+		equal  bool
+	}{
+		{"identical", func(m map[string]interface{}) {}, true},
+		{"different NodeID", func(m map[string]interface{}) { m["NodeID"] = "node_2" }, true},
+		{"different fee", func(m map[string]interface{}) { m["NagFee"] = 0.50000001 }, true},
+		{"prefix and case", func(m map[string]interface{}) { m["From"] = "SENDER"; m["ID"] = "0xABC123" }, true},
+		{"nonce as json.Number", func(m map[string]interface{}) { m["Nonce"] = json.Number("42") }, true},
+		{"different Payload", func(m map[string]interface{}) { m["Payload"] = "7b2278227d" }, false},
+		{"different Nonce", func(m map[string]interface{}) { m["Nonce"] = float64(43) }, false},
+		{"different signature", func(m map[string]interface{}) { m["OSignature"] = "3046" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := baseTransactionMap()
+			tt.modify(other)
+
+			if got := TransactionsEqual(baseTransactionMap(), other); got != tt.equal {
+				t.Errorf("TransactionsEqual() = %v, expected %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestTransaction_Equal(t *testing.T) {
+	a := &Transaction{ID: "abc", Payload: "7b7d", Nonce: "1", From: "x", To: "y", OSignature: "30", NodeID: "n1", NagFee: 0.5}
+	sameOnNodeB := *a
+	sameOnNodeB.NodeID = "n2"
+	sameOnNodeB.ProcessingFee = 7
+	tampered := *a
+	tampered.Payload = "7b2278227d"
+
+	if !a.Equal(&sameOnNodeB) {
+		t.Error("Transactions differing only in node metadata should be equal")
+	}
+	if a.Equal(&tampered) {
+		t.Error("Transactions differing in Payload should not be equal")
+	}
+	if a.Equal(nil) {
+		t.Error("A transaction should not equal nil")
+	}
+}
@@ -2,7 +2,15 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
 )
 
 // NetworkConfig holds the configuration for different networks
@@ -19,12 +27,62 @@ type NetworkConfig struct {
 	NagURLs map[string]string `json:"nag_urls"`
 }
 
+// ClientConfig holds the optional tuning of the NAG client. Durations use the
+// time.ParseDuration syntax, e.g. "10s" or "500ms"; omitted fields keep the defaults.
+type ClientConfig struct {
+	Timeout       string  `json:"timeout"`        // The request timeout.
+	RetryAttempts *int    `json:"retry_attempts"` // The number of retries of failed requests.
+	RetryDelay    string  `json:"retry_delay"`    // The delay between retries.
+	RPS           float64 `json:"rps"`            // The maximum number of requests per second.
+
+	timeout    time.Duration
+	retryDelay time.Duration
+}
+
+// parse validates the durations of the client configuration.
+func (cc *ClientConfig) parse() error {
+	var err error
+	if cc.Timeout != "" {
+		if cc.timeout, err = time.ParseDuration(cc.Timeout); err != nil {
+			return fmt.Errorf("invalid client timeout: %w", err)
+		}
+	}
+	if cc.RetryDelay != "" {
+		if cc.retryDelay, err = time.ParseDuration(cc.RetryDelay); err != nil {
+			return fmt.Errorf("invalid client retry delay: %w", err)
+		}
+	}
+	if cc.RetryAttempts != nil && *cc.RetryAttempts < 0 {
+		return fmt.Errorf("invalid client retry attempts: %d", *cc.RetryAttempts)
+	}
+	return nil
+}
+
+// apply configures c with the retry and rate limit settings. The timeout is applied
+// through the account, so that SetTimeout can override it.
+func (cc *ClientConfig) apply(c *client.Client) {
+	if cc.RetryAttempts != nil {
+		c.SetRetryAttempts(*cc.RetryAttempts)
+	}
+	if cc.retryDelay > 0 {
+		c.SetRetryDelay(cc.retryDelay)
+	}
+	if cc.RPS > 0 {
+		c.SetRateLimit(cc.RPS)
+	}
+}
+
 // Config holds the complete configuration
 type Config struct {
 	Testnet NetworkConfig `json:"testnet"`
+	Client  *ClientConfig `json:"client,omitempty"`
 }
 
 // LoadConfig loads configuration from a JSON file
+//
+// References of the form ${NAME} in the string fields are replaced with the value
+// of the environment variable NAME, so secrets such as private keys need not be
+// stored in the file. Referencing an unset variable is an error.
 func LoadConfig(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -36,10 +94,134 @@ func LoadConfig(configPath string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := config.expandEnv(); err != nil {
+		return nil, err
+	}
+	if config.Client != nil {
+		if err := config.Client.parse(); err != nil {
+			return nil, err
+		}
+	}
 
 	return &config, nil
 }
 
+// envReference matches a ${NAME} environment variable reference.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the environment variable references in the string fields of c.
+func (c *Config) expandEnv() error {
+	cfg := &c.Testnet
+	fields := map[string]*string{
+		"testnet.main_account.private_key":      &cfg.MainAccount.PrivateKey,
+		"testnet.main_account.seed_phrase":      &cfg.MainAccount.SeedPhrase,
+		"testnet.secondary_account.private_key": &cfg.SecondaryAccount.PrivateKey,
+		"testnet.secondary_account.seed_phrase": &cfg.SecondaryAccount.SeedPhrase,
+		"testnet.network":                       &cfg.Network,
+	}
+	if c.Client != nil {
+		fields["client.timeout"] = &c.Client.Timeout
+		fields["client.retry_delay"] = &c.Client.RetryDelay
+	}
+	for field, value := range fields {
+		expanded, err := expandEnvReferences(*value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		*value = expanded
+	}
+	for network, nagURL := range cfg.NagURLs {
+		expanded, err := expandEnvReferences(nagURL)
+		if err != nil {
+			return fmt.Errorf("testnet.nag_urls.%s: %w", network, err)
+		}
+		cfg.NagURLs[network] = expanded
+	}
+	return nil
+}
+
+// expandEnvReferences replaces the ${NAME} references in s with the values of the
+// environment variables, returning an error for the first unset one.
+func expandEnvReferences(s string) (string, error) {
+	var missing string
+	expanded := envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envReference.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("environment variable %s is not set", missing)
+	}
+	return expanded, nil
+}
+
+// ValidateConfig loads the configuration file at path and validates it with
+// Config.Validate, so a broken configuration is reported before it is used.
+func ValidateConfig(path string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return config.Validate()
+}
+
+// Validate checks the configuration up front: the network must be a known one, at
+// least one NAG URL must be set, every NAG URL must be an absolute HTTP(S) URL for a
+// known network, and the account keys and seed phrases, when set, must be
+// well-formed. It returns every problem found as ValidationErrors.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	cfg := c.Testnet
+
+	if cfg.Network != "" && !Network(cfg.Network).valid() {
+		errs = append(errs, ValidationError{Field: "testnet.network", Reason: fmt.Sprintf("unknown network %q", cfg.Network)})
+	}
+
+	if len(cfg.NagURLs) == 0 {
+		errs = append(errs, ValidationError{Field: "testnet.nag_urls", Reason: "must contain at least one NAG URL"})
+	}
+	networks := make([]string, 0, len(cfg.NagURLs))
+	for network := range cfg.NagURLs {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	for _, network := range networks {
+		field := "testnet.nag_urls." + network
+		if !Network(network).valid() {
+			errs = append(errs, ValidationError{Field: field, Reason: fmt.Sprintf("unknown network %q", network)})
+		}
+		if u, err := url.Parse(cfg.NagURLs[network]); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, ValidationError{Field: field, Reason: "must be an absolute http or https URL"})
+		}
+	}
+
+	errs = append(errs, validateKeyPair("testnet.main_account", cfg.MainAccount.PrivateKey, cfg.MainAccount.SeedPhrase)...)
+	errs = append(errs, validateKeyPair("testnet.secondary_account", cfg.SecondaryAccount.PrivateKey, cfg.SecondaryAccount.SeedPhrase)...)
+	return errs.asError()
+}
+
+// validateKeyPair checks the optional private key and seed phrase of the account
+// configured at field: a private key must pass ValidatePrivateKey, and a seed phrase is a
+// BIP-39 mnemonic of 12 to 24 lowercase words.
+func validateKeyPair(field, privateKey, seedPhrase string) ValidationErrors {
+	var errs ValidationErrors
+	if privateKey != "" {
+		if err := ValidatePrivateKey(privateKey); err != nil {
+			errs = append(errs, ValidationError{Field: field + ".private_key", Reason: err.Error(), err: err})
+		}
+	}
+	if seedPhrase != "" {
+		words := strings.Fields(seedPhrase)
+		if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 || seedPhrase != strings.ToLower(seedPhrase) {
+			errs = append(errs, ValidationError{Field: field + ".seed_phrase", Reason: "must be 12, 15, 18, 21 or 24 lowercase words"})
+		}
+	}
+	return errs
+}
+
 // GetNAGURL returns the NAG URL for a given network
 func (c *Config) GetNAGURL(network string) string {
 	switch network {
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a configuration file holding content and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestNewAccountWithConfig_ClientTuning(t *testing.T) {
+	path := writeConfig(t, `{
+		"testnet": {"network": "testnet"},
+		"client": {"timeout": "7s", "retry_attempts": 1, "retry_delay": "1ms", "rps": 1000}
+	}`)
+
+	account, err := NewAccountWithConfig(path)
+	if err != nil {
+		t.Fatalf("NewAccountWithConfig failed: %v", err)
+	}
+	if account.requestTimeout() != 7*time.Second {
+		t.Errorf("Expected a 7s request timeout, got %v", account.requestTimeout())
+	}
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	account.Open("0xtest_wallet_address")
+	account.network = "testnet"
+	account.client = account.newClient(server.URL)
+	if _, err := account.UpdateAccount(); err == nil {
+		t.Fatal("Expected UpdateAccount to fail")
+	}
+	if calls != 2 {
+		t.Errorf("Expected 1 retry from the configuration (2 requests), got %d requests", calls)
+	}
+}
+
+func TestLoadConfig_InvalidClientTuning(t *testing.T) {
+	for _, client := range []string{
+		`{"timeout": "soon"}`,
+		`{"retry_delay": "10"}`,
+		`{"retry_attempts": -1}`,
+	} {
+		path := writeConfig(t, `{"client": `+client+`}`)
+		if _, err := LoadConfig(path); err == nil {
+			t.Errorf("Expected an error for client configuration %s", client)
+		}
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig("../testdata/testnet_config.json"); err != nil {
+		t.Errorf("The test configuration should be valid, got %v", err)
+	}
+
+	path := writeConfig(t, `{"testnet": {"network": "testnet", "nag_urls": {"testnet": "https://nag.example.com"}}}`)
+	if err := ValidateConfig(path); err != nil {
+		t.Errorf("A configuration without accounts should be valid, got %v", err)
+	}
+}
+
+func TestValidateConfig_MissingNAGURLs(t *testing.T) {
+	path := writeConfig(t, `{"testnet": {"network": "testnet"}}`)
+
+	err := ValidateConfig(path)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if len(errs) != 1 || errs[0].Field != "testnet.nag_urls" {
+		t.Errorf("Expected a testnet.nag_urls error, got %v", errs)
+	}
+}
+
+func TestValidateConfig_Malformed(t *testing.T) {
+	path := writeConfig(t, `{"testnet": {
+		"main_account": {"private_key": "not-a-key", "seed_phrase": "too few words"},
+		"network": "moonnet",
+		"nag_urls": {"testnet": "nag-testnet.circular.io"}
+	}}`)
+
+	fields := validationFields(t, ValidateConfig(path))
+	expected := []string{
+		"testnet.network",
+		"testnet.nag_urls.testnet",
+		"testnet.main_account.private_key",
+		"testnet.main_account.seed_phrase",
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected errors for %v, got %v", expected, fields)
+	}
+	for i, field := range expected {
+		if fields[i][0] != field {
+			t.Errorf("Error %d: expected field %s, got %v", i, field, fields[i])
+		}
+	}
+}
+
+func TestLoadConfig_EnvSubstitution(t *testing.T) {
+	t.Setenv("CIRCULAR_TEST_KEY", testPrivateKey)
+	t.Setenv("CIRCULAR_TEST_HOST", "nag.example.com")
+	path := writeConfig(t, `{"testnet": {
+		"main_account": {"private_key": "${CIRCULAR_TEST_KEY}"},
+		"nag_urls": {"testnet": "https://${CIRCULAR_TEST_HOST}/api"}
+	}}`)
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Testnet.MainAccount.PrivateKey != testPrivateKey {
+		t.Errorf("Expected the private key from the environment, got %q", config.Testnet.MainAccount.PrivateKey)
+	}
+	if got := config.GetNAGURL("testnet"); got != "https://nag.example.com/api" {
+		t.Errorf("Expected the expanded NAG URL, got %q", got)
+	}
+}
+
+func TestLoadConfig_EnvSubstitutionMissing(t *testing.T) {
+	path := writeConfig(t, `{"testnet": {"main_account": {"private_key": "${CIRCULAR_TEST_UNSET_KEY}"}}}`)
+
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "CIRCULAR_TEST_UNSET_KEY") {
+		t.Errorf("Expected an error naming the unset variable, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MapToTransactionResponse converts a transaction response decoded into a map, as
+// returned by map-based clients, into a TransactionResponse.
+//
+// Numbers may be float64, json.Number or numeric strings, and the Nonce may be a
+// number or a string. It returns an error if Response is not a transaction object
+// or a numeric field holds something that is not a number.
+func MapToTransactionResponse(m map[string]interface{}) (*TransactionResponse, error) {
+	result, err := mapFloat(m, "Result")
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := m["Response"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Response is not a transaction object: %v", m["Response"])
+	}
+
+	resp := &TransactionResponse{
+		Result:  int(result),
+		Node:    mapString(m, "Node"),
+		Message: mapString(m, "message"),
+	}
+	tx := &resp.Response
+	for field, dst := range transactionStringFields(tx) {
+		*dst = mapString(inner, field)
+	}
+	for field, dst := range transactionFloatFields(tx) {
+		if *dst, err = mapFloat(inner, field); err != nil {
+			return nil, err
+		}
+	}
+	instructions, err := mapFloat(inner, "Instructions")
+	if err != nil {
+		return nil, err
+	}
+	tx.Instructions = int(instructions)
+	return resp, nil
+}
+
+// TransactionResponseToMap converts r into the map form used by map-based clients.
+//
+// The map has the shape of a decoded NAG response: numbers are float64 and the
+// message is only present when set.
+func TransactionResponseToMap(r *TransactionResponse) map[string]interface{} {
+	tx := r.Response
+	inner := map[string]interface{}{
+		"Instructions": float64(tx.Instructions),
+	}
+	for field, src := range transactionStringFields(&tx) {
+		inner[field] = *src
+	}
+	for field, src := range transactionFloatFields(&tx) {
+		inner[field] = *src
+	}
+
+	m := map[string]interface{}{
+		"Result":   float64(r.Result),
+		"Response": inner,
+		"Node":     r.Node,
+	}
+	if r.Message != "" {
+		m["message"] = r.Message
+	}
+	return m
+}
+
+// transactionStringFields maps the JSON names of the string fields of tx to them.
+func transactionStringFields(tx *Transaction) map[string]*string {
+	return map[string]*string{
+		"BlockID":    &tx.BlockID,
+		"From":       &tx.From,
+		"ID":         &tx.ID,
+		"NodeID":     &tx.NodeID,
+		"Nonce":      &tx.Nonce,
+		"OSignature": &tx.OSignature,
+		"Payload":    &tx.Payload,
+		"Status":     &tx.Status,
+		"Timestamp":  &tx.Timestamp,
+		"To":         &tx.To,
+		"Type":       &tx.Type,
+	}
+}
+
+// transactionFloatFields maps the JSON names of the float64 fields of tx to them.
+func transactionFloatFields(tx *Transaction) map[string]*float64 {
+	return map[string]*float64{
+		"BroadcastFee":  &tx.BroadcastFee,
+		"DeveloperFee":  &tx.DeveloperFee,
+		"GasLimit":      &tx.GasLimit,
+		"NagFee":        &tx.NagFee,
+		"ProcessingFee": &tx.ProcessingFee,
+		"ProtocolFee":   &tx.ProtocolFee,
+	}
+}
+
+// mapFloat returns m[key] as a float64, accepting float64, integer, json.Number and
+// numeric string values. A missing key or empty string yields zero.
+func mapFloat(m map[string]interface{}, key string) (float64, error) {
+	var f float64
+	var err error
+	switch v := m[key].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	case json.Number:
+		f, err = v.Float64()
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		f, err = strconv.ParseFloat(v, 64)
+	default:
+		err = fmt.Errorf("unexpected type %T", v)
+	}
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("field %s is not a number: %v", key, m[key])
+	}
+	return f, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMapToTransactionResponse(t *testing.T) {
+	var m map[string]interface{}
+	body := `{
+		"Result": 200,
+		"Response": {
+			"BlockID": "0xblock", "BroadcastFee": 1.5, "DeveloperFee": "0.25", "From": "0xfrom",
+			"GasLimit": 100, "ID": "0xid", "Instructions": 12, "NagFee": 0.5, "NodeID": "node-1",
+			"Nonce": 42, "OSignature": "3045", "Payload": "7b7d", "ProcessingFee": 7,
+			"ProtocolFee": 3, "Status": "Executed", "Timestamp": "2025:01:02-03:04:05",
+			"To": "0xto", "Type": "C_TYPE_CERTIFICATE"
+		},
+		"Node": "node-1"
+	}`
+	if err := json.Unmarshal([]byte(body), &m); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	resp, err := MapToTransactionResponse(m)
+	if err != nil {
+		t.Fatalf("MapToTransactionResponse failed: %v", err)
+	}
+
+	expected := Transaction{
+		BlockID: "0xblock", BroadcastFee: 1.5, DeveloperFee: 0.25, From: "0xfrom",
+		GasLimit: 100, ID: "0xid", Instructions: 12, NagFee: 0.5, NodeID: "node-1",
+		Nonce: "42", OSignature: "3045", Payload: "7b7d", ProcessingFee: 7,
+		ProtocolFee: 3, Status: "Executed", Timestamp: "2025:01:02-03:04:05",
+		To: "0xto", Type: "C_TYPE_CERTIFICATE",
+	}
+	if resp.Result != 200 || resp.Node != "node-1" {
+		t.Errorf("Unexpected envelope: Result %d, Node %q", resp.Result, resp.Node)
+	}
+	if resp.Response != expected {
+		t.Errorf("Transaction = %+v, expected %+v", resp.Response, expected)
+	}
+
+	back := TransactionResponseToMap(resp)
+	again, err := MapToTransactionResponse(back)
+	if err != nil {
+		t.Fatalf("MapToTransactionResponse of the converted map failed: %v", err)
+	}
+	if !reflect.DeepEqual(again, resp) {
+		t.Errorf("Round trip changed the response: %+v, expected %+v", again, resp)
+	}
+	if back["Response"].(map[string]interface{})["Nonce"] != "42" {
+		t.Errorf("Nonce should be converted back as a string, got %v", back["Response"].(map[string]interface{})["Nonce"])
+	}
+}
+
+func TestMapToTransactionResponseInvalid(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"not found":  {"Result": 200.0, "Response": "Transaction Not Found"},
+		"bad fee":    {"Result": 200.0, "Response": map[string]interface{}{"NagFee": "cheap"}},
+		"bad result": {"Result": true, "Response": map[string]interface{}{}},
+	}
+
+	for name, m := range tests {
+		if _, err := MapToTransactionResponse(m); err == nil {
+			t.Errorf("%s: MapToTransactionResponse should fail", name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TxCursor pages backward through the transactions of a blockchain, newest first.
+// It is created by NewLatestTransactionsCursor and is not safe for concurrent use.
+type TxCursor struct {
+	account  *Account
+	pageSize int
+	started  bool
+	// next is the height of the next block to read; it is negative once the oldest
+	// block has been read.
+	next int64
+	// pending holds the transactions of the last block read that were not returned
+	// yet, newest first.
+	pending []Transaction
+}
+
+// NewLatestTransactionsCursor returns a cursor over the transactions of the
+// configured blockchain, from the latest block backward, in pages of pageSize
+// transactions. No request is made until the first call to Next.
+func (a *Account) NewLatestTransactionsCursor(pageSize int) *TxCursor {
+	return &TxCursor{account: a, pageSize: pageSize}
+}
+
+// Next returns the next page of transactions and whether more may follow.
+//
+// Transactions are returned newest first, reading the blocks from the latest one at
+// the time of the first call down to the genesis block, so blocks created later do
+// not shift the pages. A page is shorter than the page size only when the oldest
+// block has been reached, after which more is false and further calls return empty
+// pages. If a block cannot be fetched, Next returns the error and leaves the cursor
+// unchanged, so the call can be retried.
+func (c *TxCursor) Next() ([]Transaction, bool, error) {
+	if c.pageSize <= 0 {
+		return nil, false, fmt.Errorf("invalid page size %d: must be positive", c.pageSize)
+	}
+	if !c.started {
+		count, err := c.account.GetBlockCount()
+		if err != nil {
+			return nil, false, err
+		}
+		c.next, c.started = count-1, true
+	}
+
+	page := []Transaction{}
+	next, pending := c.next, c.pending
+	for len(page) < c.pageSize {
+		if len(pending) == 0 {
+			if next < 0 {
+				break
+			}
+			block, err := c.account.GetBlock(next)
+			if err != nil {
+				return nil, c.more(), err
+			}
+			pending = slices.Clone(block.Response.Block.Transactions)
+			slices.Reverse(pending)
+			next--
+			continue
+		}
+		take := min(c.pageSize-len(page), len(pending))
+		page = append(page, pending[:take]...)
+		pending = pending[take:]
+	}
+
+	c.next, c.pending = next, pending
+	return page, c.more(), nil
+}
+
+// more reports whether transactions may remain to be returned.
+func (c *TxCursor) more() bool {
+	return len(c.pending) > 0 || c.next >= 0
+}
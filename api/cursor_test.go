@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newBlocksHistoryNAGAccount returns an account whose mock NAG holds blocks with the given
+// transaction IDs, oldest block first, and counts the block count lookups.
+func newBlocksHistoryNAGAccount(t *testing.T, blocks [][]string, countLookups *int) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetBlockCount_"):
+			*countLookups++
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Blocks": len(blocks)}})
+		case strings.Contains(r.URL.Path, "Circular_GetBlock_"):
+			height, _ := strconv.Atoi(request["BlockNumber"].(string))
+			transactions := []map[string]interface{}{}
+			for _, id := range blocks[height] {
+				transactions = append(transactions, map[string]interface{}{"ID": id})
+			}
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"Block": map[string]interface{}{"BlockNumber": height, "Transactions": transactions}},
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+}
+
+// transactionIDs returns the IDs of txs.
+func transactionIDs(txs []Transaction) string {
+	ids := make([]string, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestAccount_NewLatestTransactionsCursor(t *testing.T) {
+	countLookups := 0
+	account := newBlocksHistoryNAGAccount(t, [][]string{{"a", "b"}, {}, {"c", "d", "e"}}, &countLookups)
+	cursor := account.NewLatestTransactionsCursor(2)
+
+	expected := []struct {
+		ids  string
+		more bool
+	}{
+		{"e,d", true},
+		{"c,b", true},
+		{"a", false},
+		{"", false},
+	}
+	for i, want := range expected {
+		page, more, err := cursor.Next()
+		if err != nil {
+			t.Fatalf("Next failed on page %d: %v", i, err)
+		}
+		if ids := transactionIDs(page); ids != want.ids || more != want.more {
+			t.Errorf("Page %d: got %q (more %v), expected %q (more %v)", i, ids, more, want.ids, want.more)
+		}
+	}
+	if countLookups != 1 {
+		t.Errorf("The block count should be fetched once, got %d lookups", countLookups)
+	}
+}
+
+func TestAccount_NewLatestTransactionsCursorInvalidPageSize(t *testing.T) {
+	countLookups := 0
+	account := newBlocksHistoryNAGAccount(t, [][]string{{"a"}}, &countLookups)
+
+	if _, _, err := account.NewLatestTransactionsCursor(0).Next(); err == nil {
+		t.Error("A zero page size should be rejected")
+	}
+}
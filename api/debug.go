@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange is a raw request/response pair captured by debug capture.
+type Exchange struct {
+	Time       time.Time // When the response was received.
+	Method     string    // The HTTP method of the request.
+	URL        string    // The full URL of the request.
+	Request    []byte    // The request body, with private key material redacted.
+	Response   []byte    // The response body.
+	StatusCode int       // The HTTP status code of the response.
+	// KeyFingerprint identifies, for signed transactions, the key that signed them;
+	// see KeyFingerprint. It is empty for other requests.
+	KeyFingerprint string
+}
+
+// exchangeRecorder keeps the most recent exchanges in a bounded buffer.
+type exchangeRecorder struct {
+	mu        sync.Mutex
+	limit     int
+	exchanges []Exchange
+	// keyFingerprint is the fingerprint of the key that signed the latest transaction.
+	keyFingerprint string
+}
+
+// noteKey records the fingerprint of the key signing the next transaction sent.
+func (r *exchangeRecorder) noteKey(fingerprint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keyFingerprint = fingerprint
+}
+
+// record is the client observer storing an exchange.
+func (r *exchangeRecorder) record(method, url string, request, response []byte, statusCode int) {
+	exchange := Exchange{
+		Time:       time.Now(),
+		Method:     method,
+		URL:        url,
+		Request:    redactSecrets(request),
+		Response:   append([]byte(nil), response...),
+		StatusCode: statusCode,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if bytes.Contains(request, []byte(`"Signature":`)) {
+		exchange.KeyFingerprint = r.keyFingerprint
+	}
+	r.exchanges = append(r.exchanges, exchange)
+	if len(r.exchanges) > r.limit {
+		r.exchanges = r.exchanges[len(r.exchanges)-r.limit:]
+	}
+}
+
+// EnableDebugCapture retains the raw request and response bodies of the last n NAG
+// exchanges, available through LastExchanges. Values of request fields that look like
+// private keys or seed phrases are redacted. A value of n of zero or less disables
+// capture and discards captured exchanges.
+func (a *Account) EnableDebugCapture(n int) {
+	if n <= 0 {
+		a.debug = nil
+	} else {
+		a.debug = &exchangeRecorder{limit: n}
+	}
+
+	if a.client == nil {
+		return
+	}
+	if a.debug != nil {
+		a.client.SetObserver(a.debug.record)
+	} else {
+		a.client.SetObserver(nil)
+	}
+}
+
+// LastExchanges returns the captured exchanges, oldest first. It returns nil when
+// debug capture is disabled.
+func (a *Account) LastExchanges() []Exchange {
+	if a.debug == nil {
+		return nil
+	}
+	a.debug.mu.Lock()
+	defer a.debug.mu.Unlock()
+	return append([]Exchange(nil), a.debug.exchanges...)
+}
+
+// redactSecrets replaces the values of JSON object fields whose names mention a
+// private key or seed with a placeholder. Bodies that are not JSON objects are
+// copied unchanged.
+func redactSecrets(body []byte) []byte {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return append([]byte(nil), body...)
+	}
+
+	redacted := false
+	for name := range fields {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "private") || strings.Contains(lower, "seed") {
+			fields[name] = "[REDACTED]"
+			redacted = true
+		}
+	}
+	if !redacted {
+		return append([]byte(nil), body...)
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return out
+}
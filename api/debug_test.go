@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAccount_EnableDebugCapture(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.EnableDebugCapture(2)
+
+	if _, err := account.SubmitCertificate([]byte("debug me"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+
+	exchanges := account.LastExchanges()
+	if len(exchanges) != 1 {
+		t.Fatalf("Expected 1 captured exchange, got %d", len(exchanges))
+	}
+	exchange := exchanges[0]
+	if !strings.HasSuffix(exchange.URL, "Circular_AddTransaction_testnet") || exchange.StatusCode != 200 {
+		t.Errorf("Unexpected exchange %s %s (%d)", exchange.Method, exchange.URL, exchange.StatusCode)
+	}
+	var tx SignedTransaction
+	if err := json.Unmarshal(exchange.Request, &tx); err != nil || tx.ID != submitted[0].ID {
+		t.Errorf("Captured request should hold the submitted transaction, got %s", exchange.Request)
+	}
+	if !strings.Contains(string(exchange.Response), submitted[0].ID) {
+		t.Errorf("Captured response should hold the NAG reply, got %s", exchange.Response)
+	}
+	if strings.Contains(string(exchange.Request), testPrivateKey) {
+		t.Error("Captured request must not contain the private key")
+	}
+	fingerprint, _ := KeyFingerprint(testPrivateKey)
+	if exchange.KeyFingerprint != fingerprint {
+		t.Errorf("Captured exchange should carry the key fingerprint %q, got %q", fingerprint, exchange.KeyFingerprint)
+	}
+}
+
+func TestAccount_DebugCaptureBounded(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.EnableDebugCapture(2)
+
+	for _, data := range []string{"one", "two", "three"} {
+		if _, err := account.SubmitCertificate([]byte(data), testPrivateKey); err != nil {
+			t.Fatalf("SubmitCertificate failed: %v", err)
+		}
+	}
+
+	exchanges := account.LastExchanges()
+	if len(exchanges) != 2 {
+		t.Fatalf("Capture buffer should be bounded to 2, got %d", len(exchanges))
+	}
+	if !strings.Contains(string(exchanges[1].Request), submitted[2].ID) {
+		t.Error("Capture buffer should keep the most recent exchanges")
+	}
+
+	account.EnableDebugCapture(0)
+	if account.LastExchanges() != nil {
+		t.Error("Disabling capture should discard captured exchanges")
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	redacted := string(redactSecrets([]byte(`{"PrivateKey":"abc","seed_phrase":"words","ID":"tx"}`)))
+
+	if strings.Contains(redacted, "abc") || strings.Contains(redacted, "words") {
+		t.Errorf("Secrets should be redacted, got %s", redacted)
+	}
+	if !strings.Contains(redacted, `"ID":"tx"`) {
+		t.Errorf("Other fields should be preserved, got %s", redacted)
+	}
+}
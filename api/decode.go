@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// decodeResponse decodes a JSON response body into v.
+//
+// Numbers decoded into interface{} values are kept as json.Number rather than
+// float64, so integers above 2^53 (large nonces or block numbers) keep their
+// precision; typed fields can use json.Number for the same reason.
+func decodeResponse(data []byte, v interface{}) error {
+	return decodeJSON(data, v, false)
+}
+
+// decodeJSON decodes a JSON response body into v like decodeResponse, rejecting
+// fields that v does not declare if strict is set.
+func decodeJSON(data []byte, v interface{}, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+// SetStrictDecoding makes the typed NAG responses (SubmitCertificateResponse,
+// TransactionResponse, BlockResponse, WalletResponse and AnalyticsResponse) fail to
+// decode when they contain fields this library does not know about.
+//
+// This surfaces NAG protocol changes early and is meant for testing and staging;
+// the default, lenient mode ignores unknown fields. Responses from which only a few
+// fields are read, such as nonce lookups, are always decoded leniently.
+func (a *Account) SetStrictDecoding(strict bool) {
+	a.strictDecoding = strict
+}
+
+// decode decodes a NAG response with the account's codec, leniently.
+func (a *Account) decode(data []byte, v interface{}) error {
+	return a.decodeWith(data, v, false)
+}
+
+// decodeTyped decodes a NAG response into one of the typed response structures,
+// honoring SetStrictDecoding.
+func (a *Account) decodeTyped(data []byte, v interface{}) error {
+	return a.decodeWith(data, v, a.strictDecoding)
+}
+
+// decodeWith decodes a NAG response with the account's codec; JSON responses are
+// decoded strictly if strict is set.
+func (a *Account) decodeWith(data []byte, v interface{}, strict bool) error {
+	if _, isJSON := a.codec.(JSONCodec); a.codec != nil && !isJSON {
+		return a.codec.Unmarshal(data, v)
+	}
+	return decodeJSON(data, v, strict)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeResponseUseNumber(t *testing.T) {
+	var result map[string]interface{}
+	if err := decodeResponse([]byte(`{"Nonce":9000000000000000001}`), &result); err != nil {
+		t.Fatalf("decodeResponse failed: %v", err)
+	}
+
+	number, ok := result["Nonce"].(json.Number)
+	if !ok {
+		t.Fatalf("Expected json.Number, got %T", result["Nonce"])
+	}
+	if number.String() != "9000000000000000001" {
+		t.Errorf("Nonce lost precision: %s", number)
+	}
+}
+
+func TestAccount_UpdateAccountLargeNonce(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Result":200,"Response":{"Nonce":9000000000000000}}`))
+	})
+
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	if account.nonce != "9000000000000001" {
+		t.Errorf("Nonce should round-trip exactly, got %s", account.nonce)
+	}
+}
+
+func TestAccount_UpdateAccountInvalidNonce(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Result":200,"Response":{"Nonce":-1}}`))
+	})
+
+	if ok, err := account.UpdateAccount(); ok || err == nil {
+		t.Error("UpdateAccount should reject a negative nonce")
+	}
+}
+
+func TestAccount_SetStrictDecoding(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": map[string]interface{}{
+				"Address": "test_wallet_address",
+				"Nonce":   3,
+				"Assets":  []interface{}{},
+				"Frozen":  false, // Not part of WalletResponse.
+			},
+		})
+	})
+
+	if _, err := account.GetWallet(); err != nil {
+		t.Errorf("Unknown fields should be ignored by default, got %v", err)
+	}
+
+	account.SetStrictDecoding(true)
+	if _, err := account.GetWallet(); err == nil || !strings.Contains(err.Error(), "Frozen") {
+		t.Errorf("Strict decoding should reject the unknown field, got %v", err)
+	}
+
+	account.SetStrictDecoding(false)
+	if _, err := account.GetWallet(); err != nil {
+		t.Errorf("Disabling strict decoding should accept unknown fields again, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// dedupEntry holds the outcome of a submission shared with its duplicates.
+type dedupEntry struct {
+	done    chan struct{} // Closed once the submission has completed.
+	resp    *SubmitCertificateResponse
+	err     error
+	expires time.Time // Zero while the submission is in flight.
+}
+
+// dedupCache remembers recent submissions by the hash of their data and nonce.
+type dedupCache struct {
+	mu      sync.Mutex
+	entries map[[32]byte]*dedupEntry
+}
+
+// SetDedupWindow enables deduplication of certificate submissions.
+//
+// While enabled, submitting the same data again within d of a successful submission,
+// with the nonce the account moved to after it, or while the first one is still in
+// flight, returns the original result instead of sending (and paying for) a second
+// transaction. Once the nonce changes otherwise, for instance with UpdateAccount,
+// the data is sent again. Failed submissions are not remembered. A window of zero or
+// less disables deduplication.
+func (a *Account) SetDedupWindow(d time.Duration) {
+	a.dedupWindow = d
+}
+
+// dedupKey identifies a submission by its data and the nonce it is sent with.
+func dedupKey(pdata []byte, nonce string) [32]byte {
+	return sha256.Sum256(append(append([]byte{}, pdata...), []byte("|"+nonce)...))
+}
+
+// deduplicate runs submit unless an equivalent submission is in flight or completed
+// within the dedup window, in which case that submission's result is returned.
+func (a *Account) deduplicate(pdata []byte, submit func() (*SubmitCertificateResponse, error)) (*SubmitCertificateResponse, error) {
+	if a.dedupWindow <= 0 {
+		return submit()
+	}
+
+	key := dedupKey(pdata, a.nonce)
+	now := time.Now()
+
+	a.dedup.mu.Lock()
+	if a.dedup.entries == nil {
+		a.dedup.entries = make(map[[32]byte]*dedupEntry)
+	}
+	for k, entry := range a.dedup.entries {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(a.dedup.entries, k)
+		}
+	}
+	if entry, ok := a.dedup.entries[key]; ok {
+		a.dedup.mu.Unlock()
+		<-entry.done
+		return entry.resp, entry.err
+	}
+	entry := &dedupEntry{done: make(chan struct{})}
+	a.dedup.entries[key] = entry
+	a.dedup.mu.Unlock()
+
+	entry.resp, entry.err = submit()
+
+	a.dedup.mu.Lock()
+	delete(a.dedup.entries, key)
+	if entry.err == nil {
+		// The submission advanced the nonce, so a resubmission carries the new one.
+		entry.expires = time.Now().Add(a.dedupWindow)
+		a.dedup.entries[dedupKey(pdata, a.nonce)] = entry
+	}
+	a.dedup.mu.Unlock()
+	close(entry.done)
+	return entry.resp, entry.err
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccount_SetDedupWindow(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.SetDedupWindow(50 * time.Millisecond)
+
+	first, err := account.SubmitCertificate([]byte("duplicate"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	second, err := account.SubmitCertificate([]byte("duplicate"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("duplicate SubmitCertificate failed: %v", err)
+	}
+	if len(submitted) != 1 {
+		t.Errorf("Duplicate within the window should not be sent, got %d submissions", len(submitted))
+	}
+	if second.Response.TxID != first.Response.TxID {
+		t.Errorf("Duplicate should return the original TxID %q, got %q", first.Response.TxID, second.Response.TxID)
+	}
+
+	// Different data is never deduplicated.
+	if _, err := account.SubmitCertificate([]byte("other"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	if len(submitted) != 2 {
+		t.Errorf("Different data should be sent, got %d submissions", len(submitted))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := account.SubmitCertificate([]byte("duplicate"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	if len(submitted) != 3 {
+		t.Errorf("Submission after the window should be sent, got %d submissions", len(submitted))
+	}
+}
+
+func TestAccount_DedupDisabledByDefault(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	for i := 0; i < 2; i++ {
+		if _, err := account.SubmitCertificate([]byte("duplicate"), testPrivateKey); err != nil {
+			t.Fatalf("SubmitCertificate failed: %v", err)
+		}
+	}
+	if len(submitted) != 2 {
+		t.Errorf("Without a dedup window every submission should be sent, got %d", len(submitted))
+	}
+}
+
+func TestAccount_DedupDifferentNonce(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.SetDedupWindow(time.Minute)
+
+	account.SubmitCertificate([]byte("duplicate"), testPrivateKey)
+	// The nonce changes other than by the submission itself, as with UpdateAccount.
+	account.nonce = "5"
+	account.SubmitCertificate([]byte("duplicate"), testPrivateKey)
+
+	if len(submitted) != 2 {
+		t.Errorf("Same data with a new nonce should be sent, got %d submissions", len(submitted))
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+// maxDiffCells bounds the size of the table DiffCertificates builds, the product of
+// the number of units (lines or bytes) of the two certificates.
+const maxDiffCells = 16 << 20
+
+// DiffCertificates compares the data of two certificates, typically consecutive
+// versions of a document anchored as a chain of certificates, and returns what b
+// adds to a and what it removes from it.
+//
+// Text data (valid UTF-8 without NUL bytes in both certificates) is compared line
+// by line, and added and removed hold the added and removed lines, in order, each
+// with its line terminator. Other data is compared byte by byte. Units common to
+// both certificates are those of a longest common subsequence. It returns an error
+// if a certificate is nil or the data is too large to compare.
+func DiffCertificates(a, b *Certificate) (added, removed []byte, err error) {
+	if a == nil || b == nil {
+		return nil, nil, fmt.Errorf("cannot diff a nil certificate")
+	}
+
+	split := splitBytes
+	if isText(a.data) && isText(b.data) {
+		split = splitLines
+	}
+	before, after := split(a.data), split(b.data)
+	if len(before) > 0 && len(after) > maxDiffCells/len(before) {
+		return nil, nil, fmt.Errorf("certificates are too large to diff: %d by %d units", len(before), len(after))
+	}
+
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:].
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if bytes.Equal(before[i], after[j]) {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	added, removed = []byte{}, []byte{}
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && bytes.Equal(before[i], after[j]):
+			i, j = i+1, j+1
+		case j < len(after) && (i == len(before) || common[i][j+1] >= common[i+1][j]):
+			added = append(added, after[j]...)
+			j++
+		default:
+			removed = append(removed, before[i]...)
+			i++
+		}
+	}
+	return added, removed, nil
+}
+
+// isText reports whether data is valid UTF-8 without NUL bytes.
+func isText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// splitLines splits data into lines, keeping their terminators.
+func splitLines(data []byte) [][]byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitBytes splits data into single bytes.
+func splitBytes(data []byte) [][]byte {
+	units := make([][]byte, len(data))
+	for i := range data {
+		units[i] = data[i : i+1]
+	}
+	return units
+}
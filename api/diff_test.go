@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+)
+
+// textCertificate returns a certificate holding text.
+func textCertificate(text string) *Certificate {
+	c := &Certificate{}
+	c.SetData([]byte(text))
+	return c
+}
+
+func TestDiffCertificates(t *testing.T) {
+	tests := []struct {
+		name           string
+		before, after  string
+		added, removed string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", "", ""},
+		{"insertion", "a\nc\n", "a\nb\nc\n", "b\n", ""},
+		{"deletion", "a\nb\nc\n", "a\nc\n", "", "b\n"},
+		{"replacement", "a\nb\nc\n", "a\nB\nc\nd\n", "B\nd\n", "b\n"},
+		{"from empty", "", "a\n", "a\n", ""},
+		{"unterminated last line", "a\nb", "a\nb\nc", "b\nc", "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed, err := DiffCertificates(textCertificate(tt.before), textCertificate(tt.after))
+			if err != nil {
+				t.Fatalf("DiffCertificates failed: %v", err)
+			}
+			if string(added) != tt.added || string(removed) != tt.removed {
+				t.Errorf("Got added %q and removed %q, expected %q and %q", added, removed, tt.added, tt.removed)
+			}
+		})
+	}
+}
+
+func TestDiffCertificatesBinary(t *testing.T) {
+	before, after := &Certificate{}, &Certificate{}
+	before.SetData([]byte{0x00, 0x01, 0x02, 0x03})
+	after.SetData([]byte{0x00, 0x02, 0x03, 0xFF})
+
+	added, removed, err := DiffCertificates(before, after)
+	if err != nil {
+		t.Fatalf("DiffCertificates failed: %v", err)
+	}
+	if string(added) != "\xff" || string(removed) != "\x01" {
+		t.Errorf("Got added %x and removed %x, expected ff and 01", added, removed)
+	}
+
+	if _, _, err := DiffCertificates(nil, after); err == nil {
+		t.Error("A nil certificate should be rejected")
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
+)
+
+// ErrNetworkNodeNotSet is returned by network operations when no network has been
+// configured with SetNetwork, or the account was closed since.
+var ErrNetworkNodeNotSet = errors.New("network node is not set: call SetNetwork first")
+
+// ErrUnexpectedContentType is matched by the error returned when the NAG answers
+// with something other than JSON, such as the HTML error page of a misconfigured
+// proxy. The error quotes the content type and the beginning of the body.
+var ErrUnexpectedContentType = client.ErrUnexpectedContentType
+
+// requireNetwork checks that the account has a NAG client and a network node to
+// address its requests to.
+func (a *Account) requireNetwork() error {
+	if a.client == nil || a.network == "" {
+		return ErrNetworkNodeNotSet
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccount_ErrNetworkNodeNotSet(t *testing.T) {
+	calls := 0
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	account.network = ""
+
+	if _, err := account.UpdateAccount(); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("UpdateAccount: expected ErrNetworkNodeNotSet, got %v", err)
+	}
+	if _, err := account.GetTransactionByID("tx", "0", "10"); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("GetTransactionByID: expected ErrNetworkNodeNotSet, got %v", err)
+	}
+	if _, err := account.SubmitCertificate([]byte("data"), testPrivateKey); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("SubmitCertificate: expected ErrNetworkNodeNotSet, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("No HTTP call should be made without a network node, got %d", calls)
+	}
+}
+
+func TestAccount_ErrNetworkNodeNotSetNoClient(t *testing.T) {
+	account := NewAccount()
+	account.Open("test_address")
+
+	if _, err := account.GetWallet(); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("GetWallet: expected ErrNetworkNodeNotSet, got %v", err)
+	}
+}
+
+func TestAccount_ErrUnexpectedContentType(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<!DOCTYPE html><title>Maintenance</title>"))
+	})
+
+	_, err := account.UpdateAccount()
+	if !errors.Is(err, ErrUnexpectedContentType) {
+		t.Fatalf("Expected ErrUnexpectedContentType, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Maintenance") {
+		t.Errorf("Expected a snippet of the body in the error, got %v", err)
+	}
+}
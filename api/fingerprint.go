@@ -0,0 +1,59 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// KeyFingerprint returns a short, stable identifier of a private key that is safe to
+// log: the first 8 bytes, in hex, of the SHA-256 of its uncompressed public key. It
+// lets audit logs correlate submissions with keys without revealing them.
+func KeyFingerprint(privateKeyHex string) (string, error) {
+	key, err := parsePrivateKey(privateKeyHex)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	return fingerprintOf(key.PubKey()), nil
+}
+
+// fingerprintOf returns the fingerprint of a public key, as described in KeyFingerprint.
+func fingerprintOf(pub *btcec.PublicKey) string {
+	hash := sha256.Sum256(pub.SerializeUncompressed())
+	return hex.EncodeToString(hash[:8])
+}
+
+// publicKeyFingerprint returns the fingerprint of a hex-encoded public key, in
+// compressed or uncompressed form.
+func publicKeyFingerprint(publicKeyHex string) (string, error) {
+	keyBytes, err := hex.DecodeString(utils.HexFix(publicKeyHex))
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	pub, err := btcec.ParsePubKey(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	return fingerprintOf(pub), nil
+}
+
+// keyFingerprintFor returns the fingerprint of the key the account signs with for
+// privateKey: its Signer, privateKey itself, or its cached signing key. It returns
+// an empty string if that key is unknown or invalid.
+func (a *Account) keyFingerprintFor(privateKey string) string {
+	switch {
+	case a.signer != nil:
+		fingerprint, _ := publicKeyFingerprint(a.signer.PublicKey())
+		return fingerprint
+	case privateKey != "":
+		fingerprint, _ := KeyFingerprint(privateKey)
+		return fingerprint
+	case a.signingKey != nil:
+		return fingerprintOf(a.signingKey.PubKey())
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// HealthStatus summarizes the state of the network as seen by NetworkHealth.
+type HealthStatus int
+
+const (
+	// HealthHealthy indicates the network is processing transactions normally.
+	HealthHealthy HealthStatus = iota
+	// HealthDegraded indicates the network is slow or partially unavailable.
+	HealthDegraded
+	// HealthDown indicates the network cannot be relied upon to process transactions.
+	HealthDown
+)
+
+// String returns the name of the health status.
+func (h HealthStatus) String() string {
+	switch h {
+	case HealthHealthy:
+		return "Healthy"
+	case HealthDegraded:
+		return "Degraded"
+	case HealthDown:
+		return "Down"
+	default:
+		return fmt.Sprintf("HealthStatus(%d)", int(h))
+	}
+}
+
+// Thresholds used by NetworkHealth to classify the network.
+const (
+	degradedBlockTime    = 30.0  // Average block time, in seconds, above which the network is degraded.
+	downBlockTime        = 120.0 // Average block time, in seconds, above which the network is down.
+	degradedPendingCount = 1000  // Number of pending transactions above which the network is degraded.
+	degradedNodeRatio    = 0.5   // Fraction of active nodes below which the network is degraded.
+)
+
+// GetAnalytics retrieves network statistics for the configured blockchain.
+func (a *Account) GetAnalytics() (*AnalyticsResponse, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetAnalytics_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get analytics: %w", err)
+	}
+
+	var result AnalyticsResponse
+	if err := a.decodeTyped(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get analytics (result %d): %s", result.Result, result.Message)
+	}
+	return &result, nil
+}
+
+// GetPendingTransactions retrieves the transactions of the configured blockchain
+// that are waiting to be processed.
+//
+// It returns an empty, non-nil slice when there are none.
+func (a *Account) GetPendingTransactions() ([]Transaction, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetPendingTransaction_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get pending transactions: %w", err)
+	}
+
+	var result struct {
+		Result   int           `json:"Result"`
+		Response []Transaction `json:"Response"`
+		Message  string        `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get pending transactions (result %d): %s", result.Result, result.Message)
+	}
+	if result.Response == nil {
+		result.Response = []Transaction{}
+	}
+	return result.Response, nil
+}
+
+// NetworkHealth derives a single go/no-go signal from the network analytics and
+// the pending transaction pool.
+//
+// The network is Down when no node is active or blocks take longer than two minutes,
+// Degraded when blocks take longer than 30 seconds, more than 1000 transactions are
+// pending or fewer than half of the nodes are active, and Healthy otherwise.
+// If the analytics cannot be fetched, HealthDown is returned along with the error.
+func (a *Account) NetworkHealth() (HealthStatus, error) {
+	analytics, err := a.GetAnalytics()
+	if err != nil {
+		return HealthDown, err
+	}
+	pending, err := a.GetPendingTransactions()
+	if err != nil {
+		return HealthDown, err
+	}
+
+	stats := analytics.Response
+	if stats.ActiveNodes == 0 || stats.BlockTime > downBlockTime {
+		return HealthDown, nil
+	}
+	if stats.BlockTime > degradedBlockTime || len(pending) > degradedPendingCount {
+		return HealthDegraded, nil
+	}
+	if stats.TotalNodes > 0 && float64(stats.ActiveNodes)/float64(stats.TotalNodes) < degradedNodeRatio {
+		return HealthDegraded, nil
+	}
+	return HealthHealthy, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newHealthNAGAccount returns an account whose mock NAG reports the given
+// analytics and number of pending transactions.
+func newHealthNAGAccount(t *testing.T, analytics map[string]interface{}, pending int) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetAnalytics_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": analytics})
+		case strings.Contains(r.URL.Path, "Circular_GetPendingTransaction_"):
+			txs := make([]map[string]interface{}, pending)
+			for i := range txs {
+				txs[i] = map[string]interface{}{"ID": "tx", "Status": "Pending"}
+			}
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": txs})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+}
+
+func TestAccount_NetworkHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		analytics map[string]interface{}
+		pending   int
+		expected  HealthStatus
+	}{
+		{"healthy", map[string]interface{}{"BlockTime": 5.0, "ActiveNodes": 9, "TotalNodes": 10}, 3, HealthHealthy},
+		{"slow blocks", map[string]interface{}{"BlockTime": 45.0, "ActiveNodes": 10, "TotalNodes": 10}, 0, HealthDegraded},
+		{"large backlog", map[string]interface{}{"BlockTime": 5.0, "ActiveNodes": 10, "TotalNodes": 10}, 1001, HealthDegraded},
+		{"few nodes", map[string]interface{}{"BlockTime": 5.0, "ActiveNodes": 4, "TotalNodes": 10}, 0, HealthDegraded},
+		{"stalled blocks", map[string]interface{}{"BlockTime": 300.0, "ActiveNodes": 10, "TotalNodes": 10}, 0, HealthDown},
+		{"no nodes", map[string]interface{}{"BlockTime": 5.0, "ActiveNodes": 0, "TotalNodes": 10}, 0, HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newHealthNAGAccount(t, tt.analytics, tt.pending)
+
+			status, err := account.NetworkHealth()
+			if err != nil {
+				t.Fatalf("NetworkHealth failed: %v", err)
+			}
+			if status != tt.expected {
+				t.Errorf("NetworkHealth() = %v, expected %v", status, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAccount_NetworkHealthUnreachable(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	status, err := account.NetworkHealth()
+	if err == nil {
+		t.Error("NetworkHealth should return the analytics error")
+	}
+	if status != HealthDown {
+		t.Errorf("NetworkHealth() = %v, expected %v", status, HealthDown)
+	}
+}
+
+func TestHealthStatus_String(t *testing.T) {
+	if HealthHealthy.String() != "Healthy" || HealthDegraded.String() != "Degraded" || HealthDown.String() != "Down" {
+		t.Errorf("Unexpected health status names: %v %v %v", HealthHealthy, HealthDegraded, HealthDown)
+	}
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// GetTransactionsByAddress retrieves the transactions sent or received by address
+// on the configured blockchain, between the start and end positions of its history.
+//
+// It returns an empty, non-nil slice when there are none.
+func (a *Account) GetTransactionsByAddress(address string, start, end int) ([]Transaction, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Address":    utils.HexFix(address),
+		"Start":      strconv.Itoa(start),
+		"End":        strconv.Itoa(end),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetTransactionsByAddress_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	var result struct {
+		Result   int           `json:"Result"`
+		Response []Transaction `json:"Response"`
+		Message  string        `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get transactions (result %d): %s", result.Result, result.Message)
+	}
+	if result.Response == nil {
+		result.Response = []Transaction{}
+	}
+	return result.Response, nil
+}
+
+// GetTransactionByDate retrieves the transactions sent or received by address on the
+// configured blockchain between startDate and endDate, inclusive, so auditors can pull
+// the certificates of a period without knowing block numbers.
+//
+// Dates use the YYYY:MM:DD-HH:MM:SS layout of transaction timestamps, in UTC. It
+// returns an error if a date is malformed or endDate is before startDate, and an
+// empty, non-nil slice when there are no transactions.
+func (a *Account) GetTransactionByDate(address, startDate, endDate string) ([]Transaction, error) {
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+	start, err := time.Parse(timestampLayout, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q: expected the YYYY:MM:DD-HH:MM:SS layout", startDate)
+	}
+	end, err := time.Parse(timestampLayout, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date %q: expected the YYYY:MM:DD-HH:MM:SS layout", endDate)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("invalid date range: %s is before %s", endDate, startDate)
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Address":    utils.HexFix(address),
+		"StartDate":  startDate,
+		"EndDate":    endDate,
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetTransactionsByDate_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get transactions by date: %w", err)
+	}
+
+	var result struct {
+		Result   int           `json:"Result"`
+		Response []Transaction `json:"Response"`
+		Message  string        `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get transactions by date (result %d): %s", result.Result, result.Message)
+	}
+	if result.Response == nil {
+		result.Response = []Transaction{}
+	}
+	return result.Response, nil
+}
+
+// GetAssetTransfers retrieves the transactions of address, between the start and end
+// positions of its history, that transfer the named asset.
+//
+// The NAG has no dedicated endpoint for this, so the transactions are fetched with
+// GetTransactionsByAddress and filtered client-side: a transaction is kept when its
+// payload is a JSON object whose Asset field matches asset, ignoring case.
+// It returns an empty, non-nil slice when there are none.
+func (a *Account) GetAssetTransfers(asset, address string, start, end int) ([]Transaction, error) {
+	transactions, err := a.GetTransactionsByAddress(address, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []Transaction{}
+	for _, tx := range transactions {
+		var payload struct {
+			Asset string `json:"Asset"`
+		}
+		if json.Unmarshal([]byte(utils.HexToString(tx.Payload)), &payload) != nil {
+			continue
+		}
+		if payload.Asset != "" && strings.EqualFold(payload.Asset, asset) {
+			transfers = append(transfers, tx)
+		}
+	}
+	return transfers, nil
+}
+
+// DetectNonceGaps reports the nonces missing from the transactions the account sent,
+// among those between the start and end positions of its history.
+//
+// The nonces of the transactions sent from the account's wallet are collected and
+// every value between the lowest and the highest that none of them uses is returned,
+// in increasing order. Gaps may indicate failed submissions or chain reorganizations.
+// Transactions whose nonce is not an integer are ignored.
+func (a *Account) DetectNonceGaps(start, end int) ([]int, error) {
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	transactions, err := a.GetTransactionsByAddress(a.walletAddress, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[int]bool)
+	lowest, highest := 0, -1
+	for _, tx := range transactions {
+		if normalizeHex(tx.From) != normalizeHex(a.walletAddress) {
+			continue
+		}
+		nonce, err := strconv.Atoi(strings.TrimSpace(tx.Nonce))
+		if err != nil {
+			continue
+		}
+		if len(used) == 0 || nonce < lowest {
+			lowest = nonce
+		}
+		if nonce > highest {
+			highest = nonce
+		}
+		used[nonce] = true
+	}
+
+	gaps := []int{}
+	for nonce := lowest; nonce <= highest; nonce++ {
+		if !used[nonce] {
+			gaps = append(gaps, nonce)
+		}
+	}
+	return gaps, nil
+}
+
+// GetMyPendingTransactions returns the pending transactions sent from the account's
+// wallet, so a submitter can see what is outstanding before submitting more.
+//
+// It returns an empty, non-nil slice when there are none, and an error if the
+// account is not open.
+func (a *Account) GetMyPendingTransactions() ([]Transaction, error) {
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	pending, err := a.GetPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	mine := []Transaction{}
+	for _, tx := range pending {
+		if strings.EqualFold(utils.HexFix(tx.From), utils.HexFix(a.walletAddress)) {
+			mine = append(mine, tx)
+		}
+	}
+	return mine, nil
+}
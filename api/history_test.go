@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// hexPayload returns the hex-encoded JSON of payload, as carried by a transaction.
+func hexPayload(t *testing.T, payload interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	return utils.StringToHex(string(encoded))
+}
+
+// newHistoryNAGAccount returns an account whose mock NAG serves transactions as the
+// history of every address.
+func newHistoryNAGAccount(t *testing.T, transactions []map[string]interface{}) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetTransactionsByAddress_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": transactions})
+	})
+}
+
+func TestAccount_GetAssetTransfers(t *testing.T) {
+	account := newHistoryNAGAccount(t, []map[string]interface{}{
+		{"ID": "send-cirx", "Payload": hexPayload(t, map[string]interface{}{"Action": "CP_SEND", "Asset": "CIRX", "Amount": 5})},
+		{"ID": "certificate", "Payload": hexPayload(t, map[string]interface{}{"Action": "CP_CERTIFICATE", "Data": "6869"})},
+		{"ID": "send-usdc", "Payload": hexPayload(t, map[string]interface{}{"Action": "CP_SEND", "Asset": "USDC", "Amount": 1})},
+		{"ID": "send-cirx-lower", "Payload": hexPayload(t, map[string]interface{}{"Action": "CP_SEND", "Asset": "cirx", "Amount": 2})},
+		{"ID": "garbage", "Payload": "zz"},
+	})
+
+	transfers, err := account.GetAssetTransfers("CIRX", "0xtest_wallet_address", 0, 10)
+	if err != nil {
+		t.Fatalf("GetAssetTransfers failed: %v", err)
+	}
+
+	ids := make([]string, len(transfers))
+	for i, tx := range transfers {
+		ids[i] = tx.ID
+	}
+	if expected := []string{"send-cirx", "send-cirx-lower"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("GetAssetTransfers = %v, expected %v", ids, expected)
+	}
+}
+
+func TestAccount_GetAssetTransfersNone(t *testing.T) {
+	account := newHistoryNAGAccount(t, nil)
+
+	transfers, err := account.GetAssetTransfers("CIRX", "0xtest_wallet_address", 0, 10)
+	if err != nil {
+		t.Fatalf("GetAssetTransfers failed: %v", err)
+	}
+	if transfers == nil || len(transfers) != 0 {
+		t.Errorf("Expected an empty, non-nil slice, got %#v", transfers)
+	}
+}
+
+func TestAccount_DetectNonceGaps(t *testing.T) {
+	account := newHistoryNAGAccount(t, []map[string]interface{}{
+		{"ID": "tx-5", "From": "0xtest_wallet_address", "Nonce": "5"},
+		{"ID": "tx-1", "From": "0xtest_wallet_address", "Nonce": "1"},
+		{"ID": "incoming", "From": "0xsomeone_else", "To": "0xtest_wallet_address", "Nonce": "3"},
+		{"ID": "tx-2", "From": "test_wallet_address", "Nonce": "2"},
+		{"ID": "tx-4", "From": "0xtest_wallet_address", "Nonce": "4"},
+	})
+
+	gaps, err := account.DetectNonceGaps(0, 10)
+	if err != nil {
+		t.Fatalf("DetectNonceGaps failed: %v", err)
+	}
+	if !reflect.DeepEqual(gaps, []int{3}) {
+		t.Errorf("DetectNonceGaps = %v, expected [3]", gaps)
+	}
+}
+
+func TestAccount_DetectNonceGapsNone(t *testing.T) {
+	account := newHistoryNAGAccount(t, []map[string]interface{}{
+		{"ID": "tx-7", "From": "0xtest_wallet_address", "Nonce": "7"},
+		{"ID": "tx-8", "From": "0xtest_wallet_address", "Nonce": "8"},
+	})
+
+	gaps, err := account.DetectNonceGaps(0, 10)
+	if err != nil {
+		t.Fatalf("DetectNonceGaps failed: %v", err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("Expected no gaps, got %v", gaps)
+	}
+}
+
+// newMempoolNAGAccount returns an account whose mock NAG reports the given pending
+// transactions.
+func newMempoolNAGAccount(t *testing.T, pending []map[string]interface{}) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetPendingTransaction_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": pending})
+	})
+}
+
+func TestAccount_GetMyPendingTransactions(t *testing.T) {
+	account := newMempoolNAGAccount(t, []map[string]interface{}{
+		{"ID": "mine-1", "From": "test_wallet_address", "Nonce": "4"},
+		{"ID": "other", "From": "other_wallet", "Nonce": "9"},
+		{"ID": "mine-2", "From": "0xTEST_WALLET_ADDRESS", "Nonce": "5"},
+	})
+
+	pending, err := account.GetMyPendingTransactions()
+	if err != nil {
+		t.Fatalf("GetMyPendingTransactions failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != "mine-1" || pending[1].ID != "mine-2" {
+		t.Errorf("Expected mine-1 and mine-2, got %+v", pending)
+	}
+}
+
+func TestAccount_GetMyPendingTransactionsNone(t *testing.T) {
+	account := newMempoolNAGAccount(t, []map[string]interface{}{
+		{"ID": "other", "From": "other_wallet", "Nonce": "9"},
+	})
+
+	pending, err := account.GetMyPendingTransactions()
+	if err != nil {
+		t.Fatalf("GetMyPendingTransactions failed: %v", err)
+	}
+	if pending == nil || len(pending) != 0 {
+		t.Errorf("Expected an empty, non-nil slice, got %#v", pending)
+	}
+}
+
+func TestAccount_GetTransactionByDate(t *testing.T) {
+	var request map[string]string
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetTransactionsByDate_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": []map[string]interface{}{{"ID": "first"}, {"ID": "second"}}})
+	})
+
+	transactions, err := account.GetTransactionByDate("0xaudited", "2024:05:01-00:00:00", "2024:05:01-23:59:59")
+	if err != nil {
+		t.Fatalf("GetTransactionByDate failed: %v", err)
+	}
+	if len(transactions) != 2 || transactions[1].ID != "second" {
+		t.Errorf("Unexpected transactions %+v", transactions)
+	}
+	if request["StartDate"] != "2024:05:01-00:00:00" || request["EndDate"] != "2024:05:01-23:59:59" || request["Address"] != "audited" {
+		t.Errorf("Unexpected request %v", request)
+	}
+}
+
+func TestAccount_GetTransactionByDateInvalid(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("No request should be sent, got %q", r.URL.Path)
+	})
+
+	for _, dates := range [][2]string{
+		{"2024-05-01", "2024:05:01-23:59:59"},
+		{"2024:05:01-00:00:00", "1714521600000"},
+		{"2024:05:02-00:00:00", "2024:05:01-00:00:00"},
+	} {
+		if _, err := account.GetTransactionByDate("0xaudited", dates[0], dates[1]); err == nil {
+			t.Errorf("Dates %q and %q should be rejected", dates[0], dates[1])
+		}
+	}
+}
@@ -45,7 +45,7 @@ func TestFullWorkflowIntegration(t *testing.T) {
 	}
 	
 	// Step 6: Sign the certificate data
-	privateKey := "test_private_key_for_integration"
+	privateKey := testPrivateKey
 	signedData, err := account.SignData(cert.GetData(), privateKey)
 	if err != nil {
 		t.Fatalf("Failed to sign data: %v", err)
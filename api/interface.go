@@ -0,0 +1,18 @@
+package api
+
+// AccountLike is the core method set of Account: opening a wallet, configuring the
+// network, submitting certificates and looking up their outcome. Code written
+// against it can run on Account or on any other implementation of the Circular
+// Enterprise API, such as an adapter around another SDK or a test double.
+type AccountLike interface {
+	Open(address string) error
+	SetNetwork(network string) error
+	SetBlockchain(chain string)
+	UpdateAccount() (bool, error)
+	SubmitCertificate(pdata []byte, privateKey string) (*SubmitCertificateResponse, error)
+	GetTransactionOutcome(txID string, timeoutSec int) (*TransactionResponse, error)
+	GetTransactionByID(txID, start, end string) (*TransactionResponse, error)
+	Close()
+}
+
+var _ AccountLike = (*Account)(nil)
@@ -0,0 +1,35 @@
+package api
+
+import "testing"
+
+// certifyThrough submits data through any AccountLike implementation and waits for
+// its outcome, the way application code written against the interface would.
+func certifyThrough(t *testing.T, account AccountLike, data []byte) *TransactionResponse {
+	t.Helper()
+	if err := account.Open("0xtest_wallet_address"); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	account.SetBlockchain("0xtest_blockchain")
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	resp, err := account.SubmitCertificate(data, testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	outcome, err := account.GetTransactionOutcome(resp.Response.TxID, 5)
+	if err != nil {
+		t.Fatalf("GetTransactionOutcome failed: %v", err)
+	}
+	return outcome
+}
+
+func TestAccountLike(t *testing.T) {
+	var account AccountLike = NewAccount()
+	defer account.Close()
+
+	outcome := certifyThrough(t, account, []byte("data"))
+	if outcome.Response.Status != "Executed" {
+		t.Errorf("Expected status Executed, got %q", outcome.Response.Status)
+	}
+}
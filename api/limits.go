@@ -0,0 +1,13 @@
+package api
+
+// SetMaxConcurrentRequests bounds the number of NAG requests the account has in
+// flight at once to n, protecting both the application and the NAG during batch
+// operations: excess requests queue until a slot is free. Zero or less, the
+// default, removes the bound. The event stream of SubscribeTransactions is not
+// counted. Call it before issuing requests, not while some are in flight.
+func (a *Account) SetMaxConcurrentRequests(n int) {
+	a.maxConcurrent = n
+	if a.client != nil {
+		a.client.SetMaxConcurrentRequests(n)
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAccount_SetMaxConcurrentRequests(t *testing.T) {
+	const limit = 3
+	var current, peak atomic.Int32
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc", "Status": "Executed"}})
+	})
+	account.SetMaxConcurrentRequests(limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := account.GetTransactionByID("abc", "0", "10"); err != nil {
+				t.Errorf("GetTransactionByID failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > limit {
+		t.Errorf("Expected at most %d requests in flight, got %d", limit, p)
+	}
+	if p := peak.Load(); p < 2 {
+		t.Errorf("Expected requests to run concurrently up to the limit, got a peak of %d", p)
+	}
+}
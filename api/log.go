@@ -0,0 +1,69 @@
+package api
+
+import "fmt"
+
+// appendLogTimeout bounds how long AppendToLog waits for each entry to be confirmed,
+// in seconds.
+var appendLogTimeout = 60
+
+// AppendToLog appends data to the account's append-only log of certificates: the
+// certificate links to the previous entry through its previous transaction ID and
+// block, is submitted refreshing the nonce as SubmitCertificateAutoNonce does, and
+// AppendToLog waits for it to be confirmed before recording it as the latest entry
+// for the next append.
+//
+// The first entry of a log carries no link; use SetLogHead to continue an existing
+// log. It returns the ID of the entry's transaction, and an error, leaving the log
+// head unchanged, if the submission fails or is not confirmed in time. Appends to
+// the same account must not run concurrently.
+func (a *Account) AppendToLog(data string, privateKey string) (txID string, err error) {
+	if a.walletAddress == "" {
+		return "", fmt.Errorf("account is not open")
+	}
+
+	certificate := &Certificate{}
+	certificate.SetData([]byte(data))
+	certificate.SetPreviousTxID(a.latestTxID)
+	certificate.SetPreviousBlock(a.latestBlock)
+
+	pdata := []byte(certificate.GetJSONCertificate())
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return "", err
+	}
+	var tx *SignedTransaction
+	err = a.withFreshNonce(func() (err error) {
+		tx, _, err = a.submitPayload(newCertificatePayload(pdata), privateKey)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	txID = tx.ID
+
+	outcome, err := a.GetTransactionOutcome(txID, appendLogTimeout)
+	if err != nil {
+		return txID, fmt.Errorf("log entry %s not confirmed: %w", txID, err)
+	}
+	a.latestTxID = txID
+	a.latestBlock = outcome.Response.BlockID
+	return txID, nil
+}
+
+// LatestTxID returns the ID of the transaction of the latest entry appended with
+// AppendToLog, or an empty string if there is none.
+func (a *Account) LatestTxID() string {
+	return a.latestTxID
+}
+
+// LatestBlock returns the block holding the latest entry appended with AppendToLog,
+// or an empty string if there is none.
+func (a *Account) LatestBlock() string {
+	return a.latestBlock
+}
+
+// SetLogHead sets the latest entry of the log AppendToLog appends to, so a log can be
+// continued, for instance after a restart; empty values start a new log.
+func (a *Account) SetLogHead(txID, block string) {
+	a.latestTxID = txID
+	a.latestBlock = block
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newLogNAGAccount returns an account whose mock NAG confirms every submitted
+// transaction in a block of its own, block-1 for the first, and records them.
+func newLogNAGAccount(t *testing.T, submitted *[]SignedTransaction) *Account {
+	t.Helper()
+	return newRecordingNAGAccount(t, submitted, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetWalletNonce_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": len(*submitted)}})
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_"):
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			id, _ := request["ID"].(string)
+			var block string
+			for i, tx := range *submitted {
+				if tx.ID == id {
+					block = fmt.Sprintf("block-%d", i+1)
+				}
+			}
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"ID": id, "BlockID": block, "Status": "Executed"},
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+}
+
+// logEntry decodes the certificate carried by a transaction appended with AppendToLog.
+func logEntry(t *testing.T, tx SignedTransaction) certificateJSON {
+	t.Helper()
+	var entry certificateJSON
+	if err := json.Unmarshal([]byte(certificateData(t, tx.Payload)), &entry); err != nil {
+		t.Fatalf("failed to decode log entry: %v", err)
+	}
+	return entry
+}
+
+func TestAccount_AppendToLog(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newLogNAGAccount(t, &submitted)
+
+	var ids []string
+	for _, data := range []string{"first", "second", "third"} {
+		txID, err := account.AppendToLog(data, testPrivateKey)
+		if err != nil {
+			t.Fatalf("AppendToLog(%q) failed: %v", data, err)
+		}
+		ids = append(ids, txID)
+	}
+
+	if len(submitted) != 3 {
+		t.Fatalf("Expected 3 submissions, got %d", len(submitted))
+	}
+	for i, tx := range submitted {
+		entry := logEntry(t, tx)
+		if tx.ID != ids[i] || tx.Nonce != fmt.Sprint(i+1) {
+			t.Errorf("Entry %d: unexpected transaction %s with nonce %s", i, tx.ID, tx.Nonce)
+		}
+		if i == 0 {
+			if entry.PreviousTxID != "" || entry.PreviousBlock != "" {
+				t.Errorf("The first entry should not be linked, got %+v", entry)
+			}
+			continue
+		}
+		if entry.PreviousTxID != ids[i-1] || entry.PreviousBlock != fmt.Sprintf("block-%d", i) {
+			t.Errorf("Entry %d should link to %s in block-%d, got %+v", i, ids[i-1], i, entry)
+		}
+	}
+	if account.LatestTxID() != ids[2] || account.LatestBlock() != "block-3" {
+		t.Errorf("Unexpected log head %s in %s", account.LatestTxID(), account.LatestBlock())
+	}
+}
+
+func TestAccount_AppendToLogHead(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newLogNAGAccount(t, &submitted)
+	account.SetLogHead("0xearlier", "block-0")
+
+	if _, err := account.AppendToLog("continued", testPrivateKey); err != nil {
+		t.Fatalf("AppendToLog failed: %v", err)
+	}
+	if entry := logEntry(t, submitted[0]); entry.PreviousTxID != "0xearlier" || entry.PreviousBlock != "block-0" {
+		t.Errorf("The entry should continue the log head, got %+v", entry)
+	}
+
+	if _, err := NewAccount().AppendToLog("closed", testPrivateKey); err == nil {
+		t.Error("AppendToLog should fail on an account that is not open")
+	}
+}
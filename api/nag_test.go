@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newRecordingNAGAccount returns an account whose mock NAG is a recordingNAG.
+func newRecordingNAGAccount(t *testing.T, submitted *[]SignedTransaction, other http.HandlerFunc) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, recordingNAG(t, submitted, other))
+}
+
+// recordingNAG returns a mock NAG handler that acknowledges every transaction posted
+// to the AddTransaction endpoint and appends it to *submitted. Other requests are
+// passed to other, or fail the test if other is nil. Requests are handled one at a
+// time, so other may read *submitted.
+func recordingNAG(t *testing.T, submitted *[]SignedTransaction, other http.HandlerFunc) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !strings.Contains(r.URL.Path, "Circular_AddTransaction_") {
+			if other == nil {
+				t.Errorf("Unexpected endpoint %q", r.URL.Path)
+				return
+			}
+			other(w, r)
+			return
+		}
+		tx := decodeTransaction(t, r)
+		*submitted = append(*submitted, tx)
+		acknowledgeTransaction(t, w, tx)
+	}
+}
+
+// decodeTransaction decodes the transaction posted to a mock NAG.
+func decodeTransaction(t *testing.T, r *http.Request) SignedTransaction {
+	t.Helper()
+	var tx SignedTransaction
+	if err := json.NewDecoder(r.Body).Decode(&tx); err != nil {
+		t.Errorf("failed to decode transaction: %v", err)
+	}
+	return tx
+}
+
+// acknowledgeTransaction writes the reply of a mock NAG accepting tx.
+func acknowledgeTransaction(t *testing.T, w http.ResponseWriter, tx SignedTransaction) {
+	t.Helper()
+	writeNAGResponse(t, w, map[string]interface{}{
+		"Result":   200,
+		"Response": map[string]interface{}{"TxID": tx.ID, "Timestamp": tx.Timestamp},
+	})
+}
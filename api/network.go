@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Network identifies one of the public Circular Protocol networks.
+type Network string
+
+// The public Circular Protocol networks.
+const (
+	Mainnet Network = "mainnet" // The production network.
+	Testnet Network = "testnet" // The public test network.
+	Devnet  Network = "devnet"  // The development network.
+)
+
+// String returns the network name, as expected by SetNetwork.
+func (n Network) String() string {
+	return string(n)
+}
+
+// valid reports whether n is one of the known networks.
+func (n Network) valid() bool {
+	switch n {
+	case Mainnet, Testnet, Devnet:
+		return true
+	}
+	return false
+}
+
+// SetNetworkTyped configures one of the public networks for the account.
+//
+// Unlike SetNetwork, it rejects unknown networks before contacting the network
+// discovery service, so a typo fails early. Custom networks still go through
+// SetNetwork.
+func (a *Account) SetNetworkTyped(n Network) error {
+	if !n.valid() {
+		return ValidationErrors{{Field: "network", Reason: fmt.Sprintf("unknown network %q", string(n))}}
+	}
+	return a.SetNetwork(n.String())
+}
+
+// RefreshNetwork resolves the NAG URL of the configured network again, for example
+// after a NAG failover, and switches the account to it.
+//
+// The wallet, blockchain and nonce are preserved; the cached protocol version is
+// cleared since the new NAG may run a different version. On failure the account
+// keeps its current NAG. It returns ErrNetworkNodeNotSet if no network is set.
+func (a *Account) RefreshNetwork() error {
+	if a.network == "" {
+		return ErrNetworkNodeNotSet
+	}
+	previous := a.nagURL
+	if err := a.SetNetwork(a.network); err != nil {
+		return err
+	}
+	if a.nagURL != previous {
+		a.protocolVersion = ""
+	}
+	return nil
+}
+
+// ErrInsecureNAGURL is matched by the error returned when a NAG URL that is not HTTPS
+// is configured while HTTPS is required.
+var ErrInsecureNAGURL = errors.New("NAG URL must use HTTPS")
+
+// SetRequireHTTPS sets whether the NAG URLs resolved by SetNetwork, from the network
+// discovery service or the configuration, and the stream URL of SubscribeTransactions
+// must use HTTPS. By default HTTPS is only required on mainnet, where plain HTTP
+// would expose signed transactions and wallet queries; pass false to allow an HTTP
+// NAG on mainnet, e.g. for local testing.
+func (a *Account) SetRequireHTTPS(require bool) {
+	a.requireHTTPS = &require
+}
+
+// useNAG switches the account to the NAG at nagURL for network, after checking that
+// it uses HTTPS if required.
+func (a *Account) useNAG(network, nagURL string) error {
+	if err := a.checkHTTPS(network, nagURL); err != nil {
+		return err
+	}
+
+	a.nagURL = nagURL
+	a.client = a.newClient(nagURL)
+	return nil
+}
+
+// checkHTTPS returns an error wrapping ErrInsecureNAGURL if HTTPS is required on
+// network and rawURL does not use it.
+func (a *Account) checkHTTPS(network, rawURL string) error {
+	require := network == Mainnet.String()
+	if a.requireHTTPS != nil {
+		require = *a.requireHTTPS
+	}
+	if require {
+		if u, err := url.Parse(rawURL); err != nil || !strings.EqualFold(u.Scheme, "https") {
+			return fmt.Errorf("%w: %s", ErrInsecureNAGURL, rawURL)
+		}
+	}
+	return nil
+}
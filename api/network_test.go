@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNetwork_String(t *testing.T) {
+	tests := map[Network]string{
+		Mainnet: "mainnet",
+		Testnet: "testnet",
+		Devnet:  "devnet",
+	}
+
+	for network, expected := range tests {
+		if network.String() != expected {
+			t.Errorf("String() = %q, expected %q", network.String(), expected)
+		}
+	}
+}
+
+func TestAccount_SetNetworkTyped(t *testing.T) {
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"status": "success",
+			"url":    "https://nag-" + r.URL.Query().Get("network") + ".example.com/",
+		})
+	}))
+	defer discovery.Close()
+	original := networkDiscoveryURL
+	networkDiscoveryURL = discovery.URL
+	defer func() { networkDiscoveryURL = original }()
+
+	for _, network := range []Network{Mainnet, Testnet, Devnet} {
+		account := NewAccount()
+		if err := account.SetNetworkTyped(network); err != nil {
+			t.Fatalf("SetNetworkTyped(%s) failed: %v", network, err)
+		}
+		if account.network != network.String() {
+			t.Errorf("network = %q, expected %q", account.network, network)
+		}
+		if expected := "https://nag-" + network.String() + ".example.com/"; account.nagURL != expected {
+			t.Errorf("nagURL = %q, expected %q", account.nagURL, expected)
+		}
+	}
+}
+
+func TestAccount_SetNetworkTypedUnknown(t *testing.T) {
+	account := NewAccount()
+
+	if err := account.SetNetworkTyped(Network("testent")); err == nil {
+		t.Error("SetNetworkTyped should reject an unknown network")
+	}
+	if account.network != "" {
+		t.Errorf("An unknown network should not be configured, got %q", account.network)
+	}
+}
+
+func TestAccount_RefreshNetwork(t *testing.T) {
+	nagURL := "https://nag-primary.example.com/"
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"status": "success", "url": nagURL})
+	}))
+	defer discovery.Close()
+	original := networkDiscoveryURL
+	networkDiscoveryURL = discovery.URL
+	defer func() { networkDiscoveryURL = original }()
+
+	account := NewAccount()
+	account.Open("0xwallet")
+	account.SetBlockchain("0xchain")
+	if err := account.SetNetworkTyped(Testnet); err != nil {
+		t.Fatalf("SetNetworkTyped failed: %v", err)
+	}
+	account.nonce = "17"
+
+	nagURL = "https://nag-failover.example.com/"
+	if err := account.RefreshNetwork(); err != nil {
+		t.Fatalf("RefreshNetwork failed: %v", err)
+	}
+
+	if account.nagURL != "https://nag-failover.example.com/" {
+		t.Errorf("nagURL = %q, expected the failover NAG", account.nagURL)
+	}
+	if account.network != "testnet" || account.walletAddress != "0xwallet" || account.blockchain != "0xchain" || account.nonce != "17" {
+		t.Errorf("RefreshNetwork should preserve the account state, got network %q, wallet %q, blockchain %q, nonce %q",
+			account.network, account.walletAddress, account.blockchain, account.nonce)
+	}
+}
+
+func TestAccount_RefreshNetworkNotSet(t *testing.T) {
+	if err := NewAccount().RefreshNetwork(); !errors.Is(err, ErrNetworkNodeNotSet) {
+		t.Errorf("Expected ErrNetworkNodeNotSet, got %v", err)
+	}
+}
+
+// withDiscoveredNAG points the network discovery service at nagURL for the test.
+func withDiscoveredNAG(t *testing.T, nagURL string) {
+	t.Helper()
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"status": "success", "url": nagURL})
+	}))
+	t.Cleanup(discovery.Close)
+	original := networkDiscoveryURL
+	networkDiscoveryURL = discovery.URL
+	t.Cleanup(func() { networkDiscoveryURL = original })
+}
+
+func TestAccount_SetNetworkRequiresHTTPSOnMainnet(t *testing.T) {
+	withDiscoveredNAG(t, "http://nag.example.com/")
+
+	account := NewAccount()
+	if err := account.SetNetwork("mainnet"); !errors.Is(err, ErrInsecureNAGURL) {
+		t.Errorf("Expected ErrInsecureNAGURL on mainnet, got %v", err)
+	}
+	if account.nagURL != "" || account.client != nil {
+		t.Error("A rejected NAG URL should not be configured")
+	}
+
+	for _, network := range []string{"testnet", "devnet", "local"} {
+		account := NewAccount()
+		if err := account.SetNetwork(network); err != nil {
+			t.Errorf("An HTTP NAG should be accepted on %s, got %v", network, err)
+		}
+	}
+}
+
+func TestAccount_SetRequireHTTPS(t *testing.T) {
+	withDiscoveredNAG(t, "http://localhost:8080/")
+
+	account := NewAccount()
+	account.SetRequireHTTPS(false)
+	if err := account.SetNetwork("mainnet"); err != nil {
+		t.Errorf("The override should allow an HTTP NAG on mainnet, got %v", err)
+	}
+	if account.nagURL != "http://localhost:8080/" {
+		t.Errorf("nagURL = %q, expected the HTTP NAG", account.nagURL)
+	}
+
+	account = NewAccount()
+	account.SetRequireHTTPS(true)
+	if err := account.SetNetwork("testnet"); !errors.Is(err, ErrInsecureNAGURL) {
+		t.Errorf("Requiring HTTPS should reject an HTTP NAG on testnet, got %v", err)
+	}
+}
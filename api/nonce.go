@@ -0,0 +1,209 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultMaxNonce is the largest nonce accepted when no ceiling has been set.
+const defaultMaxNonce = math.MaxInt64
+
+// SetMaxNonce sets the largest nonce a transaction may be built with; zero restores
+// the default of 2^63-1.
+//
+// A nonce above the ceiling most likely comes from a corrupted NAG response, and
+// submitting with it would only produce an unusually long, rejected transaction.
+func (a *Account) SetMaxNonce(ceiling uint64) {
+	a.maxNonce = ceiling
+}
+
+// GetNonceInt returns the account nonce as an integer, for numeric comparisons.
+//
+// The nonce is kept as a decimal string so that values of any size survive a round
+// trip through UpdateAccount; GetNonceInt returns an error if no nonce has been set
+// yet, or if the stored nonce is not a decimal integer that fits in an int64.
+func (a *Account) GetNonceInt() (int64, error) {
+	a.submitMu.RLock()
+	defer a.submitMu.RUnlock()
+	if a.nonce == "" {
+		return 0, fmt.Errorf("nonce is not set: call UpdateAccount first")
+	}
+	nonce, err := strconv.ParseInt(a.nonce, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid nonce %q: %w", a.nonce, err)
+	}
+	return nonce, nil
+}
+
+// ErrStaleNonce is returned by CheckNonceFreshness when the account nonce has already
+// been used, so a transaction built with it would be rejected as a replay.
+var ErrStaleNonce = errors.New("stale nonce")
+
+// CheckNonceFreshness compares the account nonce with the nonce the network reports
+// for the wallet, to catch a resubmission with an already consumed nonce before the
+// network rejects it as a replay.
+//
+// The account nonce must be ahead of the wallet nonce, which is the nonce of the last
+// processed transaction. Otherwise CheckNonceFreshness returns an error wrapping
+// ErrStaleNonce; call UpdateAccount to refresh the nonce. The account nonce is not
+// changed.
+func (a *Account) CheckNonceFreshness() error {
+	a.submitMu.RLock()
+	defer a.submitMu.RUnlock()
+	if a.nonce == "" {
+		return fmt.Errorf("nonce is not set: call UpdateAccount first")
+	}
+	local, ok := new(big.Int).SetString(a.nonce, 10)
+	if !ok {
+		return fmt.Errorf("invalid nonce %q: not a decimal integer", a.nonce)
+	}
+
+	remote, err := a.fetchWalletNonce()
+	if err != nil {
+		return err
+	}
+	if local.Cmp(remote) <= 0 {
+		return fmt.Errorf("%w: account nonce %s is not ahead of the wallet nonce %s, call UpdateAccount", ErrStaleNonce, local, remote)
+	}
+	return nil
+}
+
+// checkNonce verifies that nonce is a plausible, non-negative decimal integer no
+// greater than the configured ceiling.
+func (a *Account) checkNonce(nonce string) error {
+	value, ok := new(big.Int).SetString(nonce, 10)
+	if !ok {
+		return fmt.Errorf("invalid nonce %q: not a decimal integer", nonce)
+	}
+	if value.Sign() < 0 {
+		return fmt.Errorf("invalid nonce %s: negative", nonce)
+	}
+
+	ceiling := a.maxNonce
+	if ceiling == 0 {
+		ceiling = defaultMaxNonce
+	}
+	if value.Cmp(new(big.Int).SetUint64(ceiling)) > 0 {
+		return fmt.Errorf("invalid nonce %s: exceeds the ceiling of %d", nonce, ceiling)
+	}
+	return nil
+}
+
+// SetNonceStaleness sets how long SubmitCertificateAutoNonce trusts the nonce after
+// it was refreshed by UpdateAccount. Zero, the default, refreshes it before every
+// submission.
+func (a *Account) SetNonceStaleness(d time.Duration) {
+	a.nonceStaleness = d
+}
+
+// nonceStale reports whether the nonce must be refreshed before submitting.
+func (a *Account) nonceStale() bool {
+	return a.nonce == "" || a.nonceUpdatedAt.IsZero() || time.Since(a.nonceUpdatedAt) >= a.nonceStaleness
+}
+
+// advanceNonce increments the account nonce after a transaction consumed it.
+func (a *Account) advanceNonce() {
+	if nonce, ok := new(big.Int).SetString(a.nonce, 10); ok {
+		a.nonce = nonce.Add(nonce, big.NewInt(1)).String()
+	}
+}
+
+// NonceManager hands out consecutive nonces in memory, independently of the network,
+// for tests and for preparing batches of transactions offline. It is safe for
+// concurrent use; the zero value starts at nonce 0.
+type NonceManager struct {
+	mu   sync.Mutex
+	next int
+}
+
+// NewNonceManager returns a NonceManager whose first nonce is start.
+func NewNonceManager(start int) *NonceManager {
+	return &NonceManager{next: start}
+}
+
+// Next returns the next nonce and advances the sequence.
+func (m *NonceManager) Next() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := m.next
+	m.next++
+	return n
+}
+
+// peek returns the nonce the next call to Next will return, without advancing.
+func (m *NonceManager) peek() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.next
+}
+
+// Set reseeds the sequence so that the next call to Next returns n.
+func (m *NonceManager) Set(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.next = n
+}
+
+// SetNonceManager makes the account draw the nonce of each transaction it builds
+// for submission from nm, so prepared transactions carry a deterministic sequence
+// of nonces. The account nonce follows the last nonce drawn, and UpdateAccount
+// reseeds nm with the refreshed nonce. A nil manager restores the default behavior,
+// where the account keeps its own nonce.
+func (a *Account) SetNonceManager(nm *NonceManager) {
+	a.submitMu.Lock()
+	defer a.submitMu.Unlock()
+	a.nonceManager = nm
+}
+
+// consumeNonce advances the account nonce once tx, built with it, was accepted by the
+// NAG, so the next submission uses the following nonce. Transactions carrying another
+// nonce, such as relayed ones, leave it unchanged. The caller must hold the
+// submission lock.
+func (a *Account) consumeNonce(tx *SignedTransaction) {
+	if tx.Nonce == a.nonce {
+		a.advanceNonce()
+	}
+}
+
+// WaitForNonceConsumed polls the wallet until the transaction carrying nonce has been
+// processed, so a process can wait for a submission made by another one before
+// submitting a dependent transaction.
+//
+// The wallet nonce reported by GetWallet is the nonce of the last processed
+// transaction, so nonce is consumed once the wallet nonce reaches it. The wallet is
+// checked immediately and then at every poll interval. It returns an error if
+// timeoutSec elapses first or if the wallet cannot be fetched.
+func (a *Account) WaitForNonceConsumed(nonce, timeoutSec int) error {
+	consumed := func() (bool, error) {
+		wallet, err := a.GetWallet()
+		if err != nil {
+			return false, err
+		}
+		return wallet.Response.Nonce >= nonce, nil
+	}
+
+	if done, err := consumed(); done || err != nil {
+		return err
+	}
+
+	timeout := time.NewTimer(time.Duration(timeoutSec) * time.Second)
+	defer timeout.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeout.C:
+			return fmt.Errorf("timeout exceeded waiting for nonce %d to be consumed", nonce)
+		case <-ticker.C:
+			if done, err := consumed(); done || err != nil {
+				return err
+			}
+		}
+	}
+}
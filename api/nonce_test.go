@@ -0,0 +1,405 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAccount_SubmitCertificateNonceBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		nonce   string
+		wantErr string
+	}{
+		{"normal", "42", ""},
+		{"negative", "-1", "negative"},
+		{"absurdly large", strings.Repeat("9", 40), "exceeds the ceiling"},
+		{"not a number", "12abc", "not a decimal integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var submitted []SignedTransaction
+			account := newSubmitNAGAccount(t, &submitted)
+			account.nonce = tt.nonce
+
+			_, err := account.SubmitCertificate([]byte("data"), testPrivateKey)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("SubmitCertificate failed: %v", err)
+				}
+				if submitted[0].Nonce != tt.nonce {
+					t.Errorf("Submitted nonce = %q, expected %q", submitted[0].Nonce, tt.nonce)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(submitted) != 0 {
+				t.Error("A transaction with an implausible nonce should not be sent")
+			}
+		})
+	}
+}
+
+func TestAccount_SetMaxNonce(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.SetMaxNonce(100)
+
+	account.nonce = "100"
+	if _, err := account.SubmitCertificate([]byte("data"), testPrivateKey); err != nil {
+		t.Errorf("A nonce equal to the ceiling should be accepted, got %v", err)
+	}
+
+	account.nonce = "101"
+	if _, err := account.SubmitCertificate([]byte("data"), testPrivateKey); err == nil {
+		t.Error("A nonce above the ceiling should be rejected")
+	}
+}
+
+// newNonceNAGAccount returns an account whose mock NAG reports a wallet nonce of
+// *walletNonce plus the number of transactions submitted, counting lookups in
+// *lookups, and records submitted transactions.
+func newNonceNAGAccount(t *testing.T, walletNonce *int, lookups *int, submitted *[]SignedTransaction) *Account {
+	t.Helper()
+	return newRecordingNAGAccount(t, submitted, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetWalletNonce_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+			return
+		}
+		*lookups++
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": *walletNonce + len(*submitted)}})
+	})
+}
+
+func TestAccount_SubmitCertificateAutoNonce(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+	account.SetNonceStaleness(time.Minute)
+
+	// The nonce was never fetched, so it is stale.
+	if _, err := account.SubmitCertificateAutoNonce([]byte("first"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateAutoNonce failed: %v", err)
+	}
+	if lookups != 1 || submitted[0].Nonce != "11" {
+		t.Fatalf("Expected one lookup and nonce 11, got %d lookups and nonce %s", lookups, submitted[0].Nonce)
+	}
+
+	// Within the window the locally advanced nonce is used without a lookup.
+	if _, err := account.SubmitCertificateAutoNonce([]byte("second"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateAutoNonce failed: %v", err)
+	}
+	if lookups != 1 {
+		t.Errorf("A fresh nonce should not be refreshed, got %d lookups", lookups)
+	}
+	if submitted[1].Nonce != "12" {
+		t.Errorf("Second submission nonce = %s, expected 12", submitted[1].Nonce)
+	}
+
+	// Once the window has elapsed the nonce is refreshed again.
+	account.nonceUpdatedAt = time.Now().Add(-2 * time.Minute)
+	if _, err := account.SubmitCertificateAutoNonce([]byte("third"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateAutoNonce failed: %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("A stale nonce should be refreshed, got %d lookups", lookups)
+	}
+	if submitted[2].Nonce != "13" {
+		t.Errorf("Third submission nonce = %s, expected 13", submitted[2].Nonce)
+	}
+}
+
+func TestAccount_SubmitCertificateAutoNonceDefaultRefreshes(t *testing.T) {
+	walletNonce, lookups := 4, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+
+	for i := 0; i < 2; i++ {
+		if _, err := account.SubmitCertificateAutoNonce([]byte("data"), testPrivateKey); err != nil {
+			t.Fatalf("SubmitCertificateAutoNonce failed: %v", err)
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("Without a staleness window every submission should refresh the nonce, got %d lookups", lookups)
+	}
+}
+
+func TestAccount_SubmitCertificateIfNonce(t *testing.T) {
+	walletNonce, lookups := 6, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+
+	if _, err := account.SubmitCertificateIfNonce(7, []byte("mine"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateIfNonce failed: %v", err)
+	}
+	if len(submitted) != 1 || submitted[0].Nonce != "7" {
+		t.Fatalf("Expected one submission with nonce 7, got %+v", submitted)
+	}
+
+	// Another writer submits, advancing the wallet nonce behind our back.
+	walletNonce++
+
+	_, err := account.SubmitCertificateIfNonce(8, []byte("stale"), testPrivateKey)
+	if !errors.Is(err, ErrNonceMismatch) {
+		t.Errorf("Expected ErrNonceMismatch, got %v", err)
+	}
+	if len(submitted) != 1 {
+		t.Errorf("Nothing should be submitted on a nonce mismatch, got %d submissions", len(submitted))
+	}
+}
+
+func TestAccount_GetNonceInt(t *testing.T) {
+	account := NewAccount()
+	account.Open("0xtest_wallet_address")
+
+	if _, err := account.GetNonceInt(); err == nil {
+		t.Error("Expected an error for an unset nonce")
+	}
+
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	nonce, err := account.GetNonceInt()
+	if err != nil {
+		t.Fatalf("GetNonceInt failed: %v", err)
+	}
+	if nonce != 299 {
+		t.Errorf("Expected nonce 299, got %d", nonce)
+	}
+
+	for _, stored := range []string{"abc", "1.5", "99999999999999999999"} {
+		account.nonce = stored
+		if _, err := account.GetNonceInt(); err == nil {
+			t.Errorf("Expected an error for stored nonce %q", stored)
+		}
+	}
+}
+
+func TestAccount_ConcurrentSubmissionsSerialize(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+	account.SetNonceStaleness(time.Minute)
+
+	const submissions = 10
+	var wg sync.WaitGroup
+	for i := 0; i < submissions; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := account.SubmitCertificateAutoNonce([]byte(fmt.Sprintf("certificate %d", i)), testPrivateKey); err != nil {
+				t.Errorf("SubmitCertificateAutoNonce failed: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			account.GetNonceInt()
+		}()
+	}
+	wg.Wait()
+
+	if len(submitted) != submissions {
+		t.Fatalf("Expected %d submissions, got %d", submissions, len(submitted))
+	}
+	for i, tx := range submitted {
+		if expected := strconv.Itoa(11 + i); tx.Nonce != expected {
+			t.Errorf("Submission %d: expected nonce %s, got %s", i, expected, tx.Nonce)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("Expected a single nonce lookup, got %d", lookups)
+	}
+}
+
+func TestAccount_ConcurrentSubmitCertificateNonces(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+
+	const submissions = 10
+	var wg sync.WaitGroup
+	for i := 0; i < submissions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := account.SubmitCertificate([]byte(fmt.Sprintf("certificate %d", i)), testPrivateKey); err != nil {
+				t.Errorf("SubmitCertificate failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(submitted) != submissions {
+		t.Fatalf("Expected %d submissions, got %d", submissions, len(submitted))
+	}
+	seen := make(map[string]bool)
+	for i, tx := range submitted {
+		if expected := strconv.Itoa(11 + i); tx.Nonce != expected {
+			t.Errorf("Submission %d: expected nonce %s, got %s", i, expected, tx.Nonce)
+		}
+		if seen[tx.Nonce] {
+			t.Errorf("Nonce %s was used twice", tx.Nonce)
+		}
+		seen[tx.Nonce] = true
+	}
+	if account.nonce != strconv.Itoa(11+submissions) {
+		t.Errorf("The account nonce should follow the submissions, got %s", account.nonce)
+	}
+}
+
+// newWalletNonceNAGAccount returns an account whose mock NAG reports a wallet nonce
+// of start, advancing by one on every lookup while advance is set.
+func newWalletNonceNAGAccount(t *testing.T, start int, advance bool) *Account {
+	t.Helper()
+	nonce := start
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetWallet_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": nonce}})
+		if advance {
+			nonce++
+		}
+	})
+}
+
+func TestAccount_WaitForNonceConsumed(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	account := newWalletNonceNAGAccount(t, 5, true)
+	if err := account.WaitForNonceConsumed(8, 5); err != nil {
+		t.Errorf("WaitForNonceConsumed failed: %v", err)
+	}
+}
+
+func TestAccount_WaitForNonceConsumedTimeout(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	account := newWalletNonceNAGAccount(t, 5, false)
+	err := account.WaitForNonceConsumed(6, 1)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("Expected a timeout while the nonce stalls, got %v", err)
+	}
+}
+
+func TestAccount_CheckNonceFreshness(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	if err := account.CheckNonceFreshness(); err != nil {
+		t.Errorf("A freshly updated nonce should pass, got %v", err)
+	}
+}
+
+func TestAccount_CheckNonceFreshnessStale(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	// Another process consumes the nonce behind the account's back.
+	walletNonce = 11
+
+	err := account.CheckNonceFreshness()
+	if !errors.Is(err, ErrStaleNonce) {
+		t.Fatalf("Expected ErrStaleNonce, got %v", err)
+	}
+	if account.nonce != "11" {
+		t.Errorf("CheckNonceFreshness should not change the nonce, got %s", account.nonce)
+	}
+}
+
+func TestNonceManager(t *testing.T) {
+	nm := NewNonceManager(3)
+	for _, expected := range []int{3, 4, 5} {
+		if n := nm.Next(); n != expected {
+			t.Errorf("Next() = %d, expected %d", n, expected)
+		}
+	}
+
+	nm.Set(20)
+	if n := nm.Next(); n != 20 {
+		t.Errorf("Set should reseed the sequence, Next() = %d", n)
+	}
+	if n := nm.Next(); n != 21 {
+		t.Errorf("Next() = %d, expected 21", n)
+	}
+
+	var zero NonceManager
+	if n := zero.Next(); n != 0 {
+		t.Errorf("The zero value should start at 0, got %d", n)
+	}
+}
+
+func TestAccount_SetNonceManager(t *testing.T) {
+	walletNonce, lookups := 10, 0
+	var submitted []SignedTransaction
+	account := newNonceNAGAccount(t, &walletNonce, &lookups, &submitted)
+	nm := NewNonceManager(7)
+	account.SetNonceManager(nm)
+
+	original := timestamp
+	timestamp = func() string { return "2025:06:01-12:00:00" }
+	defer func() { timestamp = original }()
+
+	// Previews do not draw a nonce, and match what is then submitted.
+	var digests []string
+	for i := 0; i < 2; i++ {
+		_, digest, err := account.SigningDigest([]byte("prepared"))
+		if err != nil {
+			t.Fatalf("SigningDigest failed: %v", err)
+		}
+		digests = append(digests, digest)
+	}
+	if digests[0] != digests[1] || nm.peek() != 7 {
+		t.Errorf("SigningDigest should not draw a nonce, the manager is at %d", nm.peek())
+	}
+
+	// Submissions draw consecutive nonces without touching the network.
+	for _, data := range []string{"prepared", "next"} {
+		if _, err := account.SubmitCertificate([]byte(data), testPrivateKey); err != nil {
+			t.Fatalf("SubmitCertificate failed: %v", err)
+		}
+	}
+	if submitted[0].ID != digests[0] || submitted[0].Nonce != "7" || submitted[1].Nonce != "8" {
+		t.Errorf("Expected transaction %s with nonce 7 then nonce 8, got %+v", digests[0], submitted)
+	}
+	if lookups != 0 {
+		t.Errorf("Drawing nonces should not query the network, got %d lookups", lookups)
+	}
+
+	// UpdateAccount reseeds the manager with the refreshed nonce, following the two
+	// submissions processed by the NAG.
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	if _, err := account.SubmitCertificate([]byte("submitted"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	if submitted[2].Nonce != "13" {
+		t.Errorf("Submitted nonce = %s, expected 13", submitted[2].Nonce)
+	}
+	if n := nm.Next(); n != 14 {
+		t.Errorf("The manager should continue after the submitted nonce, got %d", n)
+	}
+}
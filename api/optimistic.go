@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// OptimisticReceipt is returned by SubmitOptimistic as soon as a certificate has been
+// accepted by the NAG, before it is confirmed. Confirm upgrades it once the
+// transaction is recorded. It is not safe for concurrent use.
+type OptimisticReceipt struct {
+	TxID        string             // The ID of the submitted transaction, computed locally.
+	Status      string             // "Pending" until Confirm reports the final status.
+	Transaction *SignedTransaction // The signed transaction that was submitted.
+	// Outcome is the confirmed transaction, set by a successful call to Confirm.
+	Outcome *TransactionResponse
+
+	account *Account
+}
+
+// SubmitOptimistic submits pdata as a certificate, like SubmitCertificate, and
+// returns a pending receipt without waiting for the transaction to be confirmed, for
+// interfaces that must respond immediately. Call Confirm on the receipt to wait for
+// the outcome.
+//
+// It returns an error if the transaction could not be built or the NAG rejected it.
+func (a *Account) SubmitOptimistic(pdata []byte, privateKey string) (*OptimisticReceipt, error) {
+	tx, _, err := a.SubmitCertificateWithTx(pdata, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &OptimisticReceipt{TxID: tx.ID, Status: "Pending", Transaction: tx, account: a}, nil
+}
+
+// Confirm waits up to timeoutSec for the transaction to be confirmed, as with
+// GetTransactionOutcome, and records its status and outcome in the receipt.
+//
+// It returns an error, leaving the receipt pending, if the outcome is not available
+// in time or the confirmed transaction has a different ID.
+func (r *OptimisticReceipt) Confirm(timeoutSec int) (*TransactionResponse, error) {
+	outcome, err := r.account.GetTransactionOutcome(r.TxID, timeoutSec)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(utils.HexFix(outcome.Response.ID), utils.HexFix(r.TxID)) {
+		return nil, fmt.Errorf("confirmed transaction %s does not match the submitted transaction %s", outcome.Response.ID, r.TxID)
+	}
+	r.Status = outcome.Response.Status
+	r.Outcome = outcome
+	return outcome, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestAccount_SubmitOptimistic(t *testing.T) {
+	account, submitted := newFakeNAGAccount(t)
+	if _, err := account.UpdateAccount(); err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+
+	receipt, err := account.SubmitOptimistic([]byte("optimistic"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitOptimistic failed: %v", err)
+	}
+	if receipt.Status != "Pending" || receipt.Outcome != nil {
+		t.Errorf("A fresh receipt should be pending, got status %q", receipt.Status)
+	}
+	if len(*submitted) != 1 || (*submitted)[0].ID != receipt.TxID {
+		t.Errorf("The receipt ID %s should be that of the submitted transaction", receipt.TxID)
+	}
+
+	outcome, err := receipt.Confirm(5)
+	if err != nil {
+		t.Fatalf("Confirm failed: %v", err)
+	}
+	if outcome.Response.ID != receipt.TxID {
+		t.Errorf("Confirmed ID %s should match the optimistic ID %s", outcome.Response.ID, receipt.TxID)
+	}
+	if receipt.Status != "Executed" || receipt.Outcome != outcome {
+		t.Errorf("Confirm should upgrade the receipt, got status %q", receipt.Status)
+	}
+}
+
+func TestAccount_SubmitOptimisticRejected(t *testing.T) {
+	account := NewAccount()
+	account.Open("0xtest_wallet_address")
+
+	if _, err := account.SubmitOptimistic(nil, testPrivateKey); err == nil {
+		t.Error("SubmitOptimistic should fail when the certificate cannot be submitted")
+	}
+}
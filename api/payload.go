@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// DecodedPayload decodes the transaction payload into the action it performs and its
+// data, reversing the encoding applied by SubmitCertificate: the payload is the hex
+// encoding of a JSON object whose Data field is itself hex-encoded.
+//
+// It returns an error if the payload or its data is not encoded that way.
+func (t *Transaction) DecodedPayload() (action string, data []byte, err error) {
+	encoded, err := hex.DecodeString(utils.HexFix(t.Payload))
+	if err != nil {
+		return "", nil, fmt.Errorf("payload is not hexadecimal: %w", err)
+	}
+
+	var payload certificatePayload
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return "", nil, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+
+	data, err = hex.DecodeString(utils.HexFix(payload.Data))
+	if err != nil {
+		return "", nil, fmt.Errorf("payload data is not hexadecimal: %w", err)
+	}
+	return payload.Action, data, nil
+}
+
+// DecodedPayload decodes the payload of the transaction in r; see
+// Transaction.DecodedPayload.
+func (r *TransactionResponse) DecodedPayload() (action string, data []byte, err error) {
+	return r.Response.DecodedPayload()
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransactionResponse_DecodedPayload(t *testing.T) {
+	account := NewAccount()
+	account.Open("0xwallet")
+	account.SetBlockchain("0xchain")
+	account.nonce = "1"
+	pdata := []byte("certificate \\x00 contents 🌍")
+
+	tx, err := account.newCertificateTransaction(pdata)
+	if err != nil {
+		t.Fatalf("newCertificateTransaction failed: %v", err)
+	}
+	resp := &TransactionResponse{Result: 200, Response: Transaction{ID: tx.ID, Payload: tx.Payload}}
+
+	action, data, err := resp.DecodedPayload()
+	if err != nil {
+		t.Fatalf("DecodedPayload failed: %v", err)
+	}
+	if action != "CP_CERTIFICATE" {
+		t.Errorf("action = %q, expected CP_CERTIFICATE", action)
+	}
+	if !bytes.Equal(data, pdata) {
+		t.Errorf("data = %q, expected %q", data, pdata)
+	}
+}
+
+func TestTransactionResponse_DecodedPayloadInvalid(t *testing.T) {
+	for _, payload := range []string{"not hex", "6869", "7b2244617461223a227a7a227d"} {
+		resp := &TransactionResponse{Response: Transaction{Payload: payload}}
+		if _, _, err := resp.DecodedPayload(); err == nil {
+			t.Errorf("DecodedPayload(%q) should fail", payload)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// Receipt is a portable proof that a certificate was recorded on-chain, holding
+// everything a third party needs to check its inclusion and authorship with Verify.
+type Receipt struct {
+	TxID        string `json:"txID"`        // The transaction ID, the SHA-256 of the signing preimage.
+	BlockID     string `json:"blockID"`     // The block the transaction was recorded in.
+	Blockchain  string `json:"blockchain"`  // The blockchain the transaction was recorded on.
+	From        string `json:"from"`        // The sending wallet address.
+	To          string `json:"to"`          // The receiving wallet address.
+	Nonce       string `json:"nonce"`       // The nonce of the transaction.
+	Timestamp   string `json:"timestamp"`   // The timestamp of the transaction.
+	Payload     string `json:"payload"`     // The hex-encoded transaction payload.
+	PayloadHash string `json:"payloadHash"` // The SHA-256 of the decoded payload, in hex.
+	Signature   string `json:"signature"`   // The hex-encoded DER signature of the transaction ID.
+	PublicKey   string `json:"publicKey"`   // The hex-encoded public key of the signer.
+	// FieldOrder is the order of the fields in the signing preimage, when it is not
+	// the default one.
+	FieldOrder []string `json:"fieldOrder,omitempty"`
+}
+
+// BuildReceipt fetches the confirmed transaction txID and assembles its receipt.
+//
+// The signer's public key is taken from the account's Signer or cached signing key;
+// if the account knows neither, PublicKey is left empty and must be filled in before
+// calling Verify. It returns an error if the transaction is not found or not yet
+// recorded in a block.
+func (a *Account) BuildReceipt(txID string) (*Receipt, error) {
+	tx, err := a.GetTransactionByID(txID, "0", "10")
+	if err != nil {
+		return nil, err
+	}
+	resp := tx.Response
+	if resp.BlockID == "" || strings.EqualFold(resp.Status, "Pending") {
+		return nil, fmt.Errorf("transaction %s is not confirmed yet", txID)
+	}
+
+	payload, err := hex.DecodeString(utils.HexFix(resp.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	payloadHash := sha256.Sum256(payload)
+
+	receipt := &Receipt{
+		TxID:        utils.HexFix(resp.ID),
+		BlockID:     resp.BlockID,
+		Blockchain:  utils.HexFix(a.blockchain),
+		From:        resp.From,
+		To:          resp.To,
+		Nonce:       resp.Nonce,
+		Timestamp:   resp.Timestamp,
+		Payload:     resp.Payload,
+		PayloadHash: hex.EncodeToString(payloadHash[:]),
+		Signature:   resp.OSignature,
+		FieldOrder:  append([]string(nil), a.txIDFieldOrder...),
+	}
+	switch {
+	case a.signer != nil:
+		receipt.PublicKey = a.signer.PublicKey()
+	case a.signingKey != nil:
+		receipt.PublicKey = hex.EncodeToString(a.signingKey.PubKey().SerializeUncompressed())
+	}
+	return receipt, nil
+}
+
+// Verify checks the receipt against the blockchain, using a, which must be configured
+// for the receipt's blockchain, to query it: the payload hash must match the payload,
+// the transaction ID must be the SHA-256 of the signing preimage rebuilt from the
+// receipt's fields, the signature of the ID must be valid for PublicKey, and the
+// block at height BlockID must hold the transaction with that signature. It returns
+// nil if every check passes, and ErrInvalidSignature if the signature does not match
+// PublicKey.
+func (r *Receipt) Verify(a *Account) error {
+	if err := r.verifyAuthorship(); err != nil {
+		return err
+	}
+	if !strings.EqualFold(utils.HexFix(a.blockchain), utils.HexFix(r.Blockchain)) {
+		return fmt.Errorf("receipt is for blockchain %s, not %s", r.Blockchain, a.blockchain)
+	}
+	height, err := strconv.ParseInt(r.BlockID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block %q: not a block height", r.BlockID)
+	}
+
+	block, err := a.GetBlock(height)
+	if err != nil {
+		return err
+	}
+	for _, tx := range block.Response.Block.Transactions {
+		if strings.EqualFold(utils.HexFix(tx.ID), utils.HexFix(r.TxID)) {
+			if !strings.EqualFold(tx.OSignature, r.Signature) {
+				return fmt.Errorf("transaction %s is recorded in block %d with another signature", r.TxID, height)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("transaction %s is not recorded in block %d", r.TxID, height)
+}
+
+// verifyAuthorship checks the receipt on its own: its payload hash, its transaction
+// ID and the signature of the ID.
+func (r *Receipt) verifyAuthorship() error {
+	if r.PublicKey == "" {
+		return errors.New("receipt carries no public key")
+	}
+
+	payload, err := hex.DecodeString(utils.HexFix(r.Payload))
+	if err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+	payloadHash := sha256.Sum256(payload)
+	if hex.EncodeToString(payloadHash[:]) != strings.ToLower(r.PayloadHash) {
+		return errors.New("payload hash does not match the payload")
+	}
+
+	tx := &SignedTransaction{
+		Blockchain: r.Blockchain,
+		From:       r.From,
+		To:         r.To,
+		Payload:    r.Payload,
+		Nonce:      r.Nonce,
+		Timestamp:  r.Timestamp,
+	}
+	account := &Account{}
+	if r.FieldOrder != nil {
+		if err := account.SetTxIDFieldOrder(r.FieldOrder); err != nil {
+			return err
+		}
+	}
+	id := sha256.Sum256([]byte(account.SigningPreimage(tx)))
+	if hex.EncodeToString(id[:]) != strings.ToLower(utils.HexFix(r.TxID)) {
+		return errors.New("transaction ID does not match the transaction fields")
+	}
+
+	valid, err := verifySignature([]byte(utils.HexFix(r.TxID)), r.Signature, r.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
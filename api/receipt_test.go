@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newConfirmedNAGAccount returns an account with a cached signing key whose mock NAG
+// reports a transaction for pdata, built and signed by the account, as executed in
+// block 7. Other blocks are empty.
+func newConfirmedNAGAccount(t *testing.T, pdata []byte) (*Account, *SignedTransaction) {
+	t.Helper()
+	var tx *SignedTransaction
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		recorded := map[string]interface{}{
+			"ID":         tx.ID,
+			"BlockID":    "7",
+			"From":       tx.From,
+			"To":         tx.To,
+			"Nonce":      tx.Nonce,
+			"Timestamp":  tx.Timestamp,
+			"Payload":    tx.Payload,
+			"OSignature": tx.Signature,
+			"Status":     "Executed",
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": recorded})
+		case strings.Contains(r.URL.Path, "Circular_GetBlock_"):
+			var request map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			transactions := []map[string]interface{}{}
+			if request["BlockNumber"] == "7" {
+				transactions = append(transactions, recorded)
+			}
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"Block": map[string]interface{}{"Transactions": transactions}},
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+	account.nonce = "4"
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+
+	var err error
+	if tx, err = account.buildCertificateTransaction(pdata, ""); err != nil {
+		t.Fatalf("failed to build transaction: %v", err)
+	}
+	return account, tx
+}
+
+func TestAccount_BuildReceipt(t *testing.T) {
+	account, tx := newConfirmedNAGAccount(t, []byte("contract"))
+
+	receipt, err := account.BuildReceipt(tx.ID)
+	if err != nil {
+		t.Fatalf("BuildReceipt failed: %v", err)
+	}
+	if receipt.TxID != tx.ID || receipt.BlockID != "7" || receipt.Signature != tx.Signature {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+	if err := receipt.Verify(account); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+
+	// A receipt survives a JSON round trip, so it can be handed to a third party.
+	encoded, _ := json.Marshal(receipt)
+	var decoded Receipt
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode receipt: %v", err)
+	}
+	if err := decoded.Verify(account); err != nil {
+		t.Errorf("Verify failed after a JSON round trip: %v", err)
+	}
+}
+
+func TestReceipt_VerifyTampered(t *testing.T) {
+	account, tx := newConfirmedNAGAccount(t, []byte("contract"))
+	receipt, err := account.BuildReceipt(tx.ID)
+	if err != nil {
+		t.Fatalf("BuildReceipt failed: %v", err)
+	}
+	other, _ := NewKeySigner(testSecondaryPrivateKey)
+
+	tests := []struct {
+		name   string
+		tamper func(r *Receipt)
+	}{
+		{"payload", func(r *Receipt) { r.Payload = "00" + r.Payload[2:] }},
+		{"payload hash", func(r *Receipt) { r.PayloadHash = "00" + r.PayloadHash[2:] }},
+		{"nonce", func(r *Receipt) { r.Nonce = "5" }},
+		{"no block", func(r *Receipt) { r.BlockID = "" }},
+		{"other block", func(r *Receipt) { r.BlockID = "8" }},
+		{"public key", func(r *Receipt) { r.PublicKey = other.PublicKey() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := *receipt
+			tt.tamper(&tampered)
+			if err := tampered.Verify(account); err == nil {
+				t.Error("Verify should fail for a tampered receipt")
+			}
+		})
+	}
+
+	tampered := *receipt
+	tampered.PublicKey = other.PublicKey()
+	if err := tampered.Verify(account); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for another key, got %v", err)
+	}
+
+	if err := receipt.Verify(account); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	account.SetBlockchain("0xother_blockchain")
+	if err := receipt.Verify(account); err == nil {
+		t.Error("Verify should fail against another blockchain")
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DualSignResult is the outcome of SubmitCertificateDualSign.
+type DualSignResult struct {
+	*SubmitCertificateResponse
+	NewSignature string // The signature produced with the new key, sent as Signature.
+	OldSignature string // The signature produced with the old key, sent as SecondarySignature.
+	// AcceptedKey is "new" or "old" depending on which signature the NAG reports as
+	// accepted, or empty if the NAG does not say.
+	AcceptedKey string
+}
+
+// SubmitCertificateDualSign submits a certificate signed by both the old and the new
+// key of an account whose key is being rotated, so that the NAG accepts the
+// transaction whichever key it currently trusts.
+//
+// The signature from newKey is sent as the transaction's Signature and the one from
+// oldKey as its SecondarySignature; both cover the same transaction ID. The signature
+// the NAG accepted, if it reports one as Response.AcceptedSignature, is recorded in
+// the result. Any Signer set on the account is not used.
+func (a *Account) SubmitCertificateDualSign(pdata []byte, oldKey, newKey string) (*DualSignResult, error) {
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	tx, err := a.newCertificateTransaction(pdata)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Signature, err = signTransactionIDWithKey(tx.ID, newKey); err != nil {
+		return nil, fmt.Errorf("new key: %w", err)
+	}
+	if tx.SecondarySignature, err = signTransactionIDWithKey(tx.ID, oldKey); err != nil {
+		return nil, fmt.Errorf("old key: %w", err)
+	}
+
+	resp, raw, err := a.sendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	a.consumeNonce(tx)
+
+	result := &DualSignResult{
+		SubmitCertificateResponse: resp,
+		NewSignature:              tx.Signature,
+		OldSignature:              tx.SecondarySignature,
+	}
+	var accepted struct {
+		Response struct {
+			AcceptedSignature string `json:"AcceptedSignature"`
+		} `json:"Response"`
+	}
+	if json.Unmarshal(raw, &accepted) == nil {
+		switch accepted.Response.AcceptedSignature {
+		case tx.Signature:
+			result.AcceptedKey = "new"
+		case tx.SecondarySignature:
+			result.AcceptedKey = "old"
+		}
+	}
+	return result, nil
+}
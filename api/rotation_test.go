@@ -0,0 +1,91 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// testSecondaryPrivateKey is the testnet secondary account key from testdata/testnet_config.json.
+const testSecondaryPrivateKey = "949f1b13f64bfcb1493a04bebc3a66cad4b26e872708a756e02730159629e0d6"
+
+// verifyHexSignature reports whether signatureHex is a valid signature of id by privateKey.
+func verifyHexSignature(t *testing.T, id, signatureHex, privateKey string) bool {
+	t.Helper()
+	der, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		t.Fatalf("signature is not hex: %v", err)
+	}
+	signature, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		t.Fatalf("signature is not DER: %v", err)
+	}
+	key, _ := parsePrivateKey(privateKey)
+	hash := sha256.Sum256([]byte(id))
+	return signature.Verify(hash[:], key.PubKey())
+}
+
+func TestAccount_SubmitCertificateDualSign(t *testing.T) {
+	var submitted SignedTransaction
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&submitted); err != nil {
+			t.Fatalf("failed to decode transaction: %v", err)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": map[string]interface{}{
+				"TxID":              submitted.ID,
+				"Timestamp":         submitted.Timestamp,
+				"AcceptedSignature": submitted.SecondarySignature,
+			},
+		})
+	})
+	account.nonce = "3"
+
+	result, err := account.SubmitCertificateDualSign([]byte("rotating"), testPrivateKey, testSecondaryPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateDualSign failed: %v", err)
+	}
+
+	if submitted.Signature == "" || submitted.SecondarySignature == "" {
+		t.Fatal("Both signatures should be present in the submitted transaction")
+	}
+	if !verifyHexSignature(t, submitted.ID, submitted.Signature, testSecondaryPrivateKey) {
+		t.Error("Signature should verify against the new key")
+	}
+	if !verifyHexSignature(t, submitted.ID, submitted.SecondarySignature, testPrivateKey) {
+		t.Error("SecondarySignature should verify against the old key")
+	}
+	if result.AcceptedKey != "old" {
+		t.Errorf("AcceptedKey = %q, expected %q", result.AcceptedKey, "old")
+	}
+	if result.Response.TxID != submitted.ID {
+		t.Errorf("Result TxID %q should match submitted ID %q", result.Response.TxID, submitted.ID)
+	}
+}
+
+func TestAccount_SubmitCertificateDualSignInvalidKey(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	if _, err := account.SubmitCertificateDualSign([]byte("data"), "not hex", testPrivateKey); err == nil {
+		t.Error("SubmitCertificateDualSign should reject an invalid old key")
+	}
+	if len(submitted) != 0 {
+		t.Error("Nothing should be submitted when a key is invalid")
+	}
+}
+
+func TestSignedTransaction_SecondarySignatureOmitted(t *testing.T) {
+	encoded, _ := json.Marshal(SignedTransaction{ID: "tx"})
+
+	var fields map[string]interface{}
+	json.Unmarshal(encoded, &fields)
+	if _, ok := fields["SecondarySignature"]; ok {
+		t.Error("SecondarySignature should be omitted from single-signed transactions")
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// DeriveAddress computes, offline, the wallet address of the account derived from
+// seedPhrase, without constructing an account. It uses the same derivation as
+// OpenFromSeed and returns ValidationErrors if the seed phrase is empty.
+func DeriveAddress(seedPhrase string) (string, error) {
+	if err := validateSeedPhrase(seedPhrase).asError(); err != nil {
+		return "", err
+	}
+	_, _, address := utils.GetKeysFromString(seedPhrase)
+	return address, nil
+}
+
+// OpenFromSeed opens the account whose keys are derived from seedPhrase and caches
+// its signing key, so certificates can be submitted without passing a private key.
+// It returns ValidationErrors if the seed phrase is empty.
+func (a *Account) OpenFromSeed(seedPhrase string) error {
+	if err := validateSeedPhrase(seedPhrase).asError(); err != nil {
+		return err
+	}
+	privateKey, _, address := utils.GetKeysFromString(seedPhrase)
+	if err := a.Open(address); err != nil {
+		return err
+	}
+	return a.CacheSigningKey(privateKey)
+}
+
+// validateSeedPhrase checks a seed phrase supplied to derive keys.
+func validateSeedPhrase(seedPhrase string) ValidationErrors {
+	if strings.TrimSpace(seedPhrase) == "" {
+		return ValidationErrors{{Field: "seedPhrase", Reason: "must not be empty"}}
+	}
+	return nil
+}
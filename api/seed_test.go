@@ -0,0 +1,39 @@
+package api
+
+import "testing"
+
+func TestDeriveAddress(t *testing.T) {
+	config, err := LoadConfig("../testdata/testnet_config.json")
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	seed := config.Testnet.MainAccount.SeedPhrase
+
+	address, err := DeriveAddress(seed)
+	if err != nil {
+		t.Fatalf("DeriveAddress failed: %v", err)
+	}
+
+	account := NewAccount()
+	if err := account.OpenFromSeed(seed); err != nil {
+		t.Fatalf("OpenFromSeed failed: %v", err)
+	}
+	if account.walletAddress != address {
+		t.Errorf("Expected the account to be opened at %s, got %s", address, account.walletAddress)
+	}
+
+	// The seed derives the account's configured private key.
+	signer, _ := NewKeySigner(config.Testnet.MainAccount.PrivateKey)
+	if got := (&KeySigner{key: account.signingKey}).PublicKey(); got != signer.PublicKey() {
+		t.Errorf("Expected the signing key of the configured private key, got public key %s", got)
+	}
+}
+
+func TestDeriveAddressEmptySeed(t *testing.T) {
+	if _, err := DeriveAddress("  "); err == nil {
+		t.Error("Expected an error for an empty seed phrase")
+	}
+	if err := NewAccount().OpenFromSeed(""); err == nil {
+		t.Error("Expected an error for an empty seed phrase")
+	}
+}
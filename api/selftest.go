@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// selfTestTimeout bounds how long RunSelfTest waits for the probe transaction.
+var selfTestTimeout = 60
+
+// SelfTestStep is the outcome of a single step of RunSelfTest.
+type SelfTestStep struct {
+	Name     string        // The name of the step, e.g. "SubmitCertificate".
+	Passed   bool          // Whether the step succeeded.
+	Duration time.Duration // How long the step took.
+	Err      error         // The error the step failed with, if any.
+}
+
+// SelfTestReport describes a run of RunSelfTest.
+type SelfTestReport struct {
+	Network string         // The network the self-test ran against.
+	TxID    string         // The ID of the probe transaction, if it was submitted.
+	Steps   []SelfTestStep // The steps that ran, in order; steps after a failure are not run.
+	Passed  bool           // Whether every step passed.
+
+	// KeyFingerprint identifies the signing key without revealing it; see KeyFingerprint.
+	KeyFingerprint string
+}
+
+// RunSelfTest exercises the full pipeline against the configured network: it
+// resolves the network, updates the nonce, signs a probe, submits it as a
+// certificate, waits for its outcome and fetches it by ID.
+//
+// The account must be open and have a network set. The report lists every step that
+// ran with its timing; the run stops at the first failing step, whose error is also
+// returned. Note that the probe certificate is a real transaction on the network.
+func (a *Account) RunSelfTest(privateKey string) (*SelfTestReport, error) {
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	if a.network == "" {
+		return nil, ErrNetworkNodeNotSet
+	}
+
+	report := &SelfTestReport{Network: a.network, KeyFingerprint: a.keyFingerprintFor(privateKey)}
+	probe := []byte("circular self-test " + time.Now().UTC().Format(time.RFC3339Nano))
+	var outcome *TransactionResponse
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"SetNetwork", func() error {
+			return a.SetNetwork(a.network)
+		}},
+		{"UpdateAccount", func() error {
+			_, err := a.UpdateAccount()
+			return err
+		}},
+		{"SignData", func() error {
+			_, err := a.SignData(probe, privateKey)
+			return err
+		}},
+		{"SubmitCertificate", func() error {
+			resp, err := a.SubmitCertificate(probe, privateKey)
+			if err != nil {
+				return err
+			}
+			report.TxID = resp.Response.TxID
+			return nil
+		}},
+		{"GetTransactionOutcome", func() error {
+			var err error
+			outcome, err = a.GetTransactionOutcome(report.TxID, selfTestTimeout)
+			return err
+		}},
+		{"GetTransactionByID", func() error {
+			resp, err := a.GetTransactionByID(report.TxID, outcome.Response.BlockID, "")
+			if err != nil {
+				return err
+			}
+			if resp.Response.ID != report.TxID {
+				return fmt.Errorf("fetched transaction %q, expected %q", resp.Response.ID, report.TxID)
+			}
+			return nil
+		}},
+	}
+
+	for _, step := range steps {
+		start := time.Now()
+		err := step.run()
+		report.Steps = append(report.Steps, SelfTestStep{
+			Name:     step.name,
+			Passed:   err == nil,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		if err != nil {
+			return report, fmt.Errorf("self-test step %s failed: %w", step.name, err)
+		}
+	}
+
+	report.Passed = true
+	return report, nil
+}
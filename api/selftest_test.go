@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newFakeNAGAccount starts a fake NAG confirming every submitted transaction
+// immediately, and a network discovery service pointing at it, and returns an open
+// testnet account using them along with the transactions submitted.
+func newFakeNAGAccount(t *testing.T) (*Account, *[]SignedTransaction) {
+	t.Helper()
+	submitted := new([]SignedTransaction)
+	nagServer := httptest.NewServer(recordingNAG(t, submitted, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetWalletNonce_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": len(*submitted)}})
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_"):
+			for _, tx := range *submitted {
+				if tx.ID != request["ID"] {
+					continue
+				}
+				writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{
+					"ID": tx.ID, "BlockID": "block_1", "From": tx.From, "To": tx.To, "Nonce": tx.Nonce,
+					"Payload": tx.Payload, "OSignature": tx.Signature, "Timestamp": tx.Timestamp, "Status": "Executed",
+				}})
+				return
+			}
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": "Transaction Not Found"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(nagServer.Close)
+
+	discovery := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"status": "success", "url": nagServer.URL})
+	}))
+	t.Cleanup(discovery.Close)
+	original := networkDiscoveryURL
+	networkDiscoveryURL = discovery.URL
+	t.Cleanup(func() { networkDiscoveryURL = original })
+
+	account := NewAccount()
+	account.Open("0xtest_wallet_address")
+	account.SetBlockchain("0xtest_blockchain")
+	if err := account.SetNetwork("testnet"); err != nil {
+		t.Fatalf("SetNetwork failed: %v", err)
+	}
+	return account, submitted
+}
+
+func TestAccount_RunSelfTest(t *testing.T) {
+	account, _ := newFakeNAGAccount(t)
+
+	report, err := account.RunSelfTest(testPrivateKey)
+	if err != nil {
+		t.Fatalf("RunSelfTest failed: %v", err)
+	}
+
+	if !report.Passed {
+		t.Error("Report should pass against the fake NAG")
+	}
+	expected := []string{"SetNetwork", "UpdateAccount", "SignData", "SubmitCertificate", "GetTransactionOutcome", "GetTransactionByID"}
+	if len(report.Steps) != len(expected) {
+		t.Fatalf("Expected %d steps, got %d", len(expected), len(report.Steps))
+	}
+	for i, step := range report.Steps {
+		if step.Name != expected[i] || !step.Passed || step.Err != nil {
+			t.Errorf("Step %d: got %+v, expected %s to pass", i, step, expected[i])
+		}
+	}
+	if report.TxID == "" {
+		t.Error("Report should record the probe transaction ID")
+	}
+	if fingerprint, _ := KeyFingerprint(testPrivateKey); report.KeyFingerprint != fingerprint {
+		t.Errorf("Report should record the key fingerprint %q, got %q", fingerprint, report.KeyFingerprint)
+	}
+}
+
+func TestAccount_RunSelfTestStopsAtFailure(t *testing.T) {
+	account, _ := newFakeNAGAccount(t)
+
+	report, err := account.RunSelfTest("not a key")
+	if err == nil {
+		t.Fatal("RunSelfTest should fail with an invalid key")
+	}
+	last := report.Steps[len(report.Steps)-1]
+	if last.Name != "SignData" || last.Passed {
+		t.Errorf("Expected the run to stop at a failed SignData step, got %+v", last)
+	}
+	if report.Passed {
+		t.Error("Report should not pass")
+	}
+}
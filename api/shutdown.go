@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrShuttingDown is returned for submissions attempted after Shutdown was called.
+var ErrShuttingDown = errors.New("account is shutting down")
+
+// beginSubmission registers an in-flight submission, failing once Shutdown has
+// been called, and then waits for the submission lock. Every successful call must
+// be paired with endSubmission.
+func (a *Account) beginSubmission() error {
+	a.lifecycleMu.Lock()
+	if a.shuttingDown {
+		a.lifecycleMu.Unlock()
+		return ErrShuttingDown
+	}
+	a.inflight.Add(1)
+	a.lifecycleMu.Unlock()
+
+	a.submitMu.Lock()
+	return nil
+}
+
+// endSubmission releases the submission lock and marks an in-flight submission as
+// complete.
+func (a *Account) endSubmission() {
+	a.submitMu.Unlock()
+	a.inflight.Done()
+}
+
+// Shutdown stops the account from accepting new submissions, waits for in-flight
+// submissions to complete and then closes the account, clearing sensitive state
+// such as a cached signing key.
+//
+// If ctx expires before the in-flight submissions complete, Shutdown returns the
+// context's error and leaves the account state untouched for the submissions still
+// running; new submissions remain rejected with ErrShuttingDown.
+func (a *Account) Shutdown(ctx context.Context) error {
+	a.lifecycleMu.Lock()
+	a.shuttingDown = true
+	a.lifecycleMu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		a.inflight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		a.Close()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAccount_ShutdownDrainsInFlight(t *testing.T) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"TxID": "tx1"}})
+	})
+	account.nonce = "1"
+
+	submitted := make(chan error, 1)
+	go func() {
+		_, err := account.SubmitCertificate([]byte("in flight"), testPrivateKey)
+		submitted <- err
+	}()
+	<-received
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- account.Shutdown(context.Background()) }()
+
+	select {
+	case <-shutdown:
+		t.Fatal("Shutdown returned before the in-flight submission completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// New submissions are rejected while shutting down.
+	if _, err := account.SubmitCertificate([]byte("late"), testPrivateKey); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected ErrShuttingDown, got %v", err)
+	}
+
+	close(release)
+	if err := <-submitted; err != nil {
+		t.Errorf("In-flight submission should complete, got %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+	if account.network != "" || account.nonce != "" {
+		t.Error("Shutdown should close the account")
+	}
+}
+
+func TestAccount_ShutdownContextExpired(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	received := make(chan struct{})
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	})
+	account.nonce = "1"
+
+	go account.SubmitCertificate([]byte("stuck"), testPrivateKey)
+	<-received
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := account.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
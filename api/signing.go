@@ -0,0 +1,349 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// ErrInvalidPrivateKey is matched by the errors returned for malformed private keys.
+var ErrInvalidPrivateKey = errors.New("invalid private key")
+
+// ValidatePrivateKey checks that hexKey, with or without a "0x" prefix, is a valid
+// secp256k1 private key: 32 bytes in hexadecimal form whose scalar lies in [1, n-1],
+// n being the order of the curve. Signing with a key failing these checks would
+// silently use a different, reduced key. The returned error matches
+// ErrInvalidPrivateKey and says which check failed.
+func ValidatePrivateKey(hexKey string) error {
+	keyBytes, err := hex.DecodeString(utils.HexFix(hexKey))
+	if err != nil {
+		return fmt.Errorf("%w: not hexadecimal", ErrInvalidPrivateKey)
+	}
+	defer wipeBytes(keyBytes)
+	return validateKeyBytes(keyBytes)
+}
+
+// validateKeyBytes checks that keyBytes is a 32-byte scalar in [1, n-1].
+func validateKeyBytes(keyBytes []byte) error {
+	if len(keyBytes) != 32 {
+		return fmt.Errorf("%w: expected 32 bytes, got %d", ErrInvalidPrivateKey, len(keyBytes))
+	}
+	var scalar btcec.ModNScalar
+	overflow := scalar.SetByteSlice(keyBytes)
+	defer scalar.Zero()
+	if overflow {
+		return fmt.Errorf("%w: not below the secp256k1 curve order", ErrInvalidPrivateKey)
+	}
+	if scalar.IsZero() {
+		return fmt.Errorf("%w: zero", ErrInvalidPrivateKey)
+	}
+	return nil
+}
+
+// parsePrivateKey decodes a hex-encoded secp256k1 private key, with or without
+// a "0x" prefix, validating it as ValidatePrivateKey does. The intermediate key
+// bytes are wiped before returning.
+func parsePrivateKey(privateKeyHex string) (*btcec.PrivateKey, error) {
+	keyBytes, err := hex.DecodeString(utils.HexFix(privateKeyHex))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not hexadecimal", ErrInvalidPrivateKey)
+	}
+	defer wipeBytes(keyBytes)
+	if err := validateKeyBytes(keyBytes); err != nil {
+		return nil, err
+	}
+	key, _ := btcec.PrivKeyFromBytes(keyBytes)
+	return key, nil
+}
+
+// wipeBytes overwrites b with zeros.
+//
+// This is best effort only: the Go runtime may already have copied the bytes
+// elsewhere (e.g. when growing a slice or moving a stack), and the original hex
+// string the key was decoded from is immutable and cannot be wiped at all.
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// signMessage hashes message with SHA-256 and signs the digest with key,
+// returning the DER-encoded signature. This matches the NodeJS signData helper.
+func signMessage(key *btcec.PrivateKey, message []byte) []byte {
+	hash := sha256.Sum256(message)
+	return ecdsa.Sign(key, hash[:]).Serialize()
+}
+
+// verifySignature reports whether signatureHex, a hex-encoded DER signature, is a
+// valid signature of the SHA-256 digest of message by publicKeyHex, a hex-encoded
+// compressed or uncompressed public key.
+func verifySignature(message []byte, signatureHex, publicKeyHex string) (bool, error) {
+	keyBytes, err := hex.DecodeString(utils.HexFix(publicKeyHex))
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+	key, err := btcec.ParsePubKey(keyBytes)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	der, err := hex.DecodeString(utils.HexFix(signatureHex))
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+	signature, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	hash := sha256.Sum256(message)
+	return signature.Verify(hash[:], key), nil
+}
+
+// signMessageRecoverable hashes message with SHA-256 and signs the digest with key,
+// returning the 65-byte compact signature: a recovery header byte followed by R and S.
+func signMessageRecoverable(key *btcec.PrivateKey, message []byte) []byte {
+	hash := sha256.Sum256(message)
+	return ecdsa.SignCompact(key, hash[:], false)
+}
+
+// SetRecoverableSignatures makes SignData produce 65-byte recoverable signatures
+// instead of DER-encoded ones, so verifiers can use RecoverPublicKey. Transactions
+// submitted to the NAG are always signed in DER form.
+func (a *Account) SetRecoverableSignatures(enabled bool) {
+	a.recoverable = enabled
+}
+
+// RecoverPublicKey recovers the public key that produced a recoverable signature
+// of data, as returned by SignData with recoverable signatures enabled.
+//
+// signatureHex is the hex-encoded 65-byte signature. It returns the signer's
+// uncompressed public key in hexadecimal form, the format of KeySigner.PublicKey.
+func RecoverPublicKey(data, signatureHex string) (string, error) {
+	signature, err := hex.DecodeString(utils.HexFix(signatureHex))
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(signature) != 65 {
+		return "", fmt.Errorf("invalid signature: expected 65 bytes, got %d", len(signature))
+	}
+	hash := sha256.Sum256([]byte(data))
+	key, _, err := ecdsa.RecoverCompact(signature, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to recover public key: %w", err)
+	}
+	return hex.EncodeToString(key.SerializeUncompressed()), nil
+}
+
+// keyID identifies a private key string without retaining the key itself.
+func keyID(privateKeyHex string) [32]byte {
+	return sha256.Sum256([]byte(utils.HexFix(privateKeyHex)))
+}
+
+// CacheSigningKey parses privateKeyHex once and keeps the decoded key on the account.
+//
+// Subsequent calls to SignData and SubmitCertificate with the same private key (or
+// with an empty private key) reuse the cached key instead of decoding it again,
+// which matters in tight submission loops. The cached key is cleared by Close.
+// It returns an error if the private key cannot be decoded.
+func (a *Account) CacheSigningKey(privateKeyHex string) error {
+	key, err := parsePrivateKey(privateKeyHex)
+	if err != nil {
+		return err
+	}
+	a.signingKey = key
+	a.signingKeyID = keyID(privateKeyHex)
+	return nil
+}
+
+// signingKeyFor returns the key to sign with for privateKeyHex, preferring the
+// cached key when it matches. cached reports whether the cached key was returned;
+// callers should Zero an uncached key once they are done with it.
+func (a *Account) signingKeyFor(privateKeyHex string) (key *btcec.PrivateKey, cached bool, err error) {
+	if a.signingKey != nil && (privateKeyHex == "" || keyID(privateKeyHex) == a.signingKeyID) {
+		return a.signingKey, true, nil
+	}
+	key, err = parsePrivateKey(privateKeyHex)
+	return key, false, err
+}
+
+// defaultTxIDFieldOrder is the order in which transaction fields are hashed into
+// transaction IDs by the reference SDKs.
+var defaultTxIDFieldOrder = []string{"Blockchain", "From", "To", "Payload", "Nonce", "Timestamp"}
+
+// DefaultTxIDFieldOrder returns the default order of the fields concatenated into
+// the transaction ID preimage: Blockchain, From, To, Payload, Nonce and Timestamp.
+func DefaultTxIDFieldOrder() []string {
+	return append([]string(nil), defaultTxIDFieldOrder...)
+}
+
+// SetTxIDFieldOrder changes the order in which transaction fields are concatenated
+// into the transaction ID preimage, for NAG deployments that expect a different order.
+//
+// fields must name each of the fields of DefaultTxIDFieldOrder exactly once. A nil
+// slice restores the default order. It returns an error, leaving the order unchanged,
+// if a field is unknown, repeated or missing.
+func (a *Account) SetTxIDFieldOrder(fields []string) error {
+	if fields == nil {
+		a.txIDFieldOrder = nil
+		return nil
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if txIDField(&SignedTransaction{}, field) == nil {
+			return fmt.Errorf("unknown transaction ID field %q", field)
+		}
+		if seen[field] {
+			return fmt.Errorf("transaction ID field %q is repeated", field)
+		}
+		seen[field] = true
+	}
+	for _, field := range defaultTxIDFieldOrder {
+		if !seen[field] {
+			return fmt.Errorf("transaction ID field %q is missing", field)
+		}
+	}
+
+	a.txIDFieldOrder = append([]string(nil), fields...)
+	return nil
+}
+
+// txIDField returns a pointer to the field of tx named field, or nil if field is
+// not part of the transaction ID preimage.
+func txIDField(tx *SignedTransaction, field string) *string {
+	switch field {
+	case "Blockchain":
+		return &tx.Blockchain
+	case "From":
+		return &tx.From
+	case "To":
+		return &tx.To
+	case "Payload":
+		return &tx.Payload
+	case "Nonce":
+		return &tx.Nonce
+	case "Timestamp":
+		return &tx.Timestamp
+	}
+	return nil
+}
+
+// SigningPreimage returns the canonical string whose SHA-256 digest is the ID of tx.
+//
+// The preimage is the concatenation, without separators, of Blockchain, From, To,
+// Payload, Nonce and Timestamp, or of the same fields in the order set with
+// SetTxIDFieldOrder. External verifiers can hash it to recompute the transaction ID
+// and check the signature over that ID.
+func (a *Account) SigningPreimage(tx *SignedTransaction) string {
+	order := a.txIDFieldOrder
+	if order == nil {
+		order = defaultTxIDFieldOrder
+	}
+
+	var preimage strings.Builder
+	for _, field := range order {
+		preimage.WriteString(*txIDField(tx, field))
+	}
+	return preimage.String()
+}
+
+// SigningDigest returns what SubmitCertificate would sign for pdata, with the
+// account's current wallet, blockchain and nonce, or the next nonce of its nonce
+// manager, without signing or submitting; the account is left unchanged.
+//
+// preimage is the canonical string described in SigningPreimage and digest its
+// SHA-256 hex digest, which is the transaction ID covered by the signature. Since
+// the preimage includes a timestamp with a resolution of one second, a submission
+// made later yields a different digest. Compliance processes can log the digest to
+// later match it against the submitted transaction.
+func (a *Account) SigningDigest(pdata []byte) (preimage string, digest string, err error) {
+	a.submitMu.RLock()
+	defer a.submitMu.RUnlock()
+	tx, err := a.previewTransaction(newCertificatePayload(pdata))
+	if err != nil {
+		return "", "", err
+	}
+	return a.SigningPreimage(tx), tx.ID, nil
+}
+
+// Signer produces transaction signatures without exposing the private key, for
+// example by delegating to a hardware security module or a remote signing service.
+type Signer interface {
+	// Sign returns the DER-encoded secp256k1 ECDSA signature of a SHA-256 digest.
+	Sign(hash []byte) ([]byte, error)
+	// PublicKey returns the signer's public key in hexadecimal form.
+	PublicKey() string
+}
+
+// KeySigner is an in-memory Signer backed by a hex-encoded private key.
+type KeySigner struct {
+	key *btcec.PrivateKey
+}
+
+// NewKeySigner creates a Signer from a hex-encoded secp256k1 private key.
+func NewKeySigner(privateKeyHex string) (*KeySigner, error) {
+	key, err := parsePrivateKey(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{key: key}, nil
+}
+
+// Sign returns the DER-encoded signature of hash.
+func (s *KeySigner) Sign(hash []byte) ([]byte, error) {
+	return ecdsa.Sign(s.key, hash).Serialize(), nil
+}
+
+// PublicKey returns the uncompressed public key in hexadecimal form.
+func (s *KeySigner) PublicKey() string {
+	return hex.EncodeToString(s.key.PubKey().SerializeUncompressed())
+}
+
+// SetSigner makes SubmitCertificate sign transactions with s instead of the private
+// key passed to it, which is then ignored. A nil signer restores the default behavior.
+func (a *Account) SetSigner(s Signer) {
+	a.signer = s
+}
+
+// signTransactionID returns the hex-encoded signature of a transaction ID, using the
+// account's Signer when one is set and privateKey otherwise.
+func (a *Account) signTransactionID(id, privateKey string) (string, error) {
+	if a.debug != nil {
+		a.debug.noteKey(a.keyFingerprintFor(privateKey))
+	}
+	if a.signer != nil {
+		hash := sha256.Sum256([]byte(id))
+		signature, err := a.signer.Sign(hash[:])
+		if err != nil {
+			return "", fmt.Errorf("signer failed: %w", err)
+		}
+		return hex.EncodeToString(signature), nil
+	}
+
+	key, cached, err := a.signingKeyFor(privateKey)
+	if err != nil {
+		return "", err
+	}
+	if !cached {
+		defer key.Zero()
+	}
+	return hex.EncodeToString(signMessage(key, []byte(id))), nil
+}
+
+// signTransactionIDWithKey returns the hex-encoded signature of a transaction ID
+// with the given private key, bypassing any Signer or cached key.
+func signTransactionIDWithKey(id, privateKey string) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	return hex.EncodeToString(signMessage(key, []byte(id))), nil
+}
@@ -0,0 +1,453 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// testPrivateKey is the testnet main account key from testdata/testnet_config.json.
+const testPrivateKey = "03bc1511837430581a9151cd6eb1b34c0dd4f8b90cb38c4b772b943a9c94717f"
+
+func TestAccount_SignDataVerifies(t *testing.T) {
+	account := NewAccount()
+	data := []byte("data to sign")
+
+	der, err := account.SignData(data, testPrivateKey)
+	if err != nil {
+		t.Fatalf("SignData failed: %v", err)
+	}
+
+	signature, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		t.Fatalf("SignData should return a DER signature: %v", err)
+	}
+	key, _ := parsePrivateKey(testPrivateKey)
+	hash := sha256.Sum256(data)
+	if !signature.Verify(hash[:], key.PubKey()) {
+		t.Error("Signature should verify against the signer's public key")
+	}
+}
+
+func TestAccount_SignDataInvalidKey(t *testing.T) {
+	account := NewAccount()
+
+	if _, err := account.SignData([]byte("data"), "not_a_hex_key"); err == nil {
+		t.Error("SignData should fail for a non-hex private key")
+	}
+}
+
+func TestAccount_CacheSigningKey(t *testing.T) {
+	account := NewAccount()
+	data := []byte("cached key data")
+
+	uncached, err := account.SignData(data, testPrivateKey)
+	if err != nil {
+		t.Fatalf("SignData failed: %v", err)
+	}
+
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+	cached, err := account.SignData(data, "")
+	if err != nil {
+		t.Fatalf("SignData with cached key failed: %v", err)
+	}
+	if !bytes.Equal(cached, uncached) {
+		t.Error("Cached key should produce the same signature as the uncached path")
+	}
+
+	account.Close()
+	if account.signingKey != nil {
+		t.Error("Close should clear the cached signing key")
+	}
+}
+
+func TestAccount_CacheSigningKeyInvalid(t *testing.T) {
+	account := NewAccount()
+
+	if err := account.CacheSigningKey("zz"); err == nil {
+		t.Error("CacheSigningKey should reject a non-hex key")
+	}
+}
+
+func TestAccount_SubmitCertificateCachedKey(t *testing.T) {
+	var submitted SignedTransaction
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&submitted); err != nil {
+			t.Fatalf("failed to decode transaction: %v", err)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"TxID": submitted.ID, "Timestamp": submitted.Timestamp},
+		})
+	})
+	account.nonce = "7"
+
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+	response, err := account.SubmitCertificate([]byte("certificate"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	if response.Response.TxID != submitted.ID {
+		t.Errorf("Response TxID %q should match submitted ID %q", response.Response.TxID, submitted.ID)
+	}
+
+	// Signing is deterministic (RFC 6979), so the uncached path must reproduce it.
+	uncached, err := NewAccount().SignData([]byte(submitted.ID), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SignData failed: %v", err)
+	}
+	if submitted.Signature != hex.EncodeToString(uncached) {
+		t.Error("Cached-key submission should produce the same signature as the uncached path")
+	}
+}
+
+func TestAccount_CloseWipesCachedKey(t *testing.T) {
+	account := NewAccount()
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		t.Fatalf("CacheSigningKey failed: %v", err)
+	}
+	key := account.signingKey
+
+	account.Close()
+
+	if account.signingKey != nil {
+		t.Error("Close should clear the cached signing key field")
+	}
+	if account.signingKeyID != ([32]byte{}) {
+		t.Error("Close should clear the cached signing key identifier")
+	}
+	if !key.Key.IsZero() {
+		t.Error("Close should zero the cached key's scalar")
+	}
+}
+
+func TestWipeBytes(t *testing.T) {
+	b := []byte{0x01, 0xFF, 0x7A}
+
+	wipeBytes(b)
+
+	for i, v := range b {
+		if v != 0 {
+			t.Errorf("wipeBytes left byte %d as %#x", i, v)
+		}
+	}
+}
+
+func TestAccount_SigningPreimage(t *testing.T) {
+	account := NewAccount()
+	tx := &SignedTransaction{
+		Blockchain: "aa",
+		From:       "bb",
+		To:         "cc",
+		Payload:    "dd",
+		Nonce:      "5",
+		Timestamp:  "2025:01:02-03:04:05",
+	}
+
+	if preimage := account.SigningPreimage(tx); preimage != "aabbccdd52025:01:02-03:04:05" {
+		t.Errorf("SigningPreimage() = %q, unexpected field order", preimage)
+	}
+}
+
+func TestAccount_SetTxIDFieldOrder(t *testing.T) {
+	tx := &SignedTransaction{
+		Blockchain: "aa",
+		From:       "bb",
+		To:         "cc",
+		Payload:    "dd",
+		Nonce:      "5",
+		Timestamp:  "2025:01:02-03:04:05",
+	}
+	account := NewAccount()
+
+	if err := account.SetTxIDFieldOrder(DefaultTxIDFieldOrder()); err != nil {
+		t.Fatalf("SetTxIDFieldOrder with the default order failed: %v", err)
+	}
+	if preimage := account.SigningPreimage(tx); preimage != "aabbccdd52025:01:02-03:04:05" {
+		t.Errorf("SigningPreimage() = %q with the default order", preimage)
+	}
+
+	custom := []string{"Timestamp", "Nonce", "Blockchain", "From", "To", "Payload"}
+	if err := account.SetTxIDFieldOrder(custom); err != nil {
+		t.Fatalf("SetTxIDFieldOrder failed: %v", err)
+	}
+	if preimage := account.SigningPreimage(tx); preimage != "2025:01:02-03:04:055aabbccdd" {
+		t.Errorf("SigningPreimage() = %q with a custom order", preimage)
+	}
+
+	// The custom order is the one the transaction ID is computed from.
+	account.Open("0xwallet")
+	account.SetBlockchain("0xchain")
+	account.nonce = "5"
+	built, err := account.newCertificateTransaction([]byte("ordered"))
+	if err != nil {
+		t.Fatalf("newCertificateTransaction failed: %v", err)
+	}
+	hash := sha256.Sum256([]byte(built.Timestamp + built.Nonce + built.Blockchain + built.From + built.To + built.Payload))
+	if built.ID != hex.EncodeToString(hash[:]) {
+		t.Error("Transaction ID should follow the custom field order")
+	}
+}
+
+func TestAccount_SetTxIDFieldOrderInvalid(t *testing.T) {
+	tests := map[string][]string{
+		"missing":  {"Blockchain", "From", "To", "Payload", "Nonce"},
+		"repeated": {"Blockchain", "From", "To", "Payload", "Nonce", "Nonce"},
+		"unknown":  {"Blockchain", "From", "To", "Payload", "Nonce", "Timestamp", "Memo"},
+	}
+
+	for name, fields := range tests {
+		t.Run(name, func(t *testing.T) {
+			account := NewAccount()
+			if err := account.SetTxIDFieldOrder(fields); err == nil {
+				t.Errorf("SetTxIDFieldOrder(%v) should fail", fields)
+			}
+			if account.txIDFieldOrder != nil {
+				t.Error("An invalid order should leave the default order in place")
+			}
+		})
+	}
+}
+
+func TestAccount_SigningDigest(t *testing.T) {
+	original := timestamp
+	timestamp = func() string { return "2025:06:01-12:00:00" }
+	defer func() { timestamp = original }()
+
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	preimage, digest, err := account.SigningDigest([]byte("audited"))
+	if err != nil {
+		t.Fatalf("SigningDigest failed: %v", err)
+	}
+	hash := sha256.Sum256([]byte(preimage))
+	if hex.EncodeToString(hash[:]) != digest {
+		t.Error("The digest should be the SHA-256 of the preimage")
+	}
+
+	if _, err := account.SubmitCertificate([]byte("audited"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	tx := submitted[0]
+	if account.SigningPreimage(&tx) != preimage || tx.ID != digest {
+		t.Errorf("Submission ID %s differs from the digest %s", tx.ID, digest)
+	}
+	if !verifyHexSignature(t, digest, tx.Signature, testPrivateKey) {
+		t.Error("The submitted signature should cover the digest")
+	}
+}
+
+func TestAccount_SigningPreimageMatchesSubmission(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	if _, err := account.SubmitCertificate([]byte("preimage check"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+
+	tx := submitted[0]
+	hash := sha256.Sum256([]byte(account.SigningPreimage(&tx)))
+	if hex.EncodeToString(hash[:]) != tx.ID {
+		t.Errorf("SHA-256 of the preimage should equal the submitted ID %q", tx.ID)
+	}
+}
+
+// fakeSigner records the digests it is asked to sign.
+type fakeSigner struct {
+	hashes [][]byte
+	inner  *KeySigner
+}
+
+func (s *fakeSigner) Sign(hash []byte) ([]byte, error) {
+	s.hashes = append(s.hashes, hash)
+	return s.inner.Sign(hash)
+}
+
+func (s *fakeSigner) PublicKey() string {
+	return s.inner.PublicKey()
+}
+
+func TestAccount_SetSigner(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	inner, err := NewKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+	signer := &fakeSigner{inner: inner}
+	account.SetSigner(signer)
+
+	// The private key argument is ignored when a signer is set.
+	if _, err := account.SubmitCertificate([]byte("hsm signed"), ""); err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+
+	if len(signer.hashes) != 1 {
+		t.Fatalf("Signer should be called once, got %d", len(signer.hashes))
+	}
+	tx := submitted[0]
+	expectedHash := sha256.Sum256([]byte(tx.ID))
+	if !bytes.Equal(signer.hashes[0], expectedHash[:]) {
+		t.Error("Signer should be asked to sign the SHA-256 of the transaction ID")
+	}
+
+	der, _ := hex.DecodeString(tx.Signature)
+	signature, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		t.Fatalf("Submitted signature is not DER: %v", err)
+	}
+	key, _ := parsePrivateKey(testPrivateKey)
+	if !signature.Verify(expectedHash[:], key.PubKey()) {
+		t.Error("Submitted signature should be the signer's signature")
+	}
+}
+
+func TestKeySigner_PublicKey(t *testing.T) {
+	signer, err := NewKeySigner(testPrivateKey)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+
+	publicKey := signer.PublicKey()
+	if len(publicKey) != 130 || publicKey[:2] != "04" {
+		t.Errorf("PublicKey() = %q, expected an uncompressed hex key", publicKey)
+	}
+}
+
+func BenchmarkSignData(b *testing.B) {
+	account := NewAccount()
+	data := []byte("benchmark data")
+	for i := 0; i < b.N; i++ {
+		if _, err := account.SignData(data, testPrivateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignDataCachedKey(b *testing.B) {
+	account := NewAccount()
+	if err := account.CacheSigningKey(testPrivateKey); err != nil {
+		b.Fatal(err)
+	}
+	data := []byte("benchmark data")
+	for i := 0; i < b.N; i++ {
+		if _, err := account.SignData(data, testPrivateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRecoverPublicKey(t *testing.T) {
+	account := NewAccount()
+	account.SetRecoverableSignatures(true)
+	signer, _ := NewKeySigner(testPrivateKey)
+
+	signature, err := account.SignData([]byte("data to sign"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SignData failed: %v", err)
+	}
+	if len(signature) != 65 {
+		t.Fatalf("Recoverable signature should be 65 bytes, got %d", len(signature))
+	}
+
+	publicKey, err := RecoverPublicKey("data to sign", hex.EncodeToString(signature))
+	if err != nil {
+		t.Fatalf("RecoverPublicKey failed: %v", err)
+	}
+	if publicKey != signer.PublicKey() {
+		t.Errorf("RecoverPublicKey = %s, expected %s", publicKey, signer.PublicKey())
+	}
+
+	if other, _ := RecoverPublicKey("other data", hex.EncodeToString(signature)); other == signer.PublicKey() {
+		t.Error("Recovering with different data should not yield the signer's key")
+	}
+}
+
+func TestRecoverPublicKeyInvalidSignature(t *testing.T) {
+	der, _ := NewAccount().SignData([]byte("data"), testPrivateKey)
+
+	if _, err := RecoverPublicKey("data", hex.EncodeToString(der)); err == nil {
+		t.Error("RecoverPublicKey should reject a DER signature")
+	}
+}
+
+func TestValidatePrivateKey(t *testing.T) {
+	if err := ValidatePrivateKey(testPrivateKey); err != nil {
+		t.Errorf("Expected the test key to be valid, got %v", err)
+	}
+	if err := ValidatePrivateKey("0x" + testPrivateKey); err != nil {
+		t.Errorf("Expected a 0x-prefixed key to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		name, key, reason string
+	}{
+		{"zero", strings.Repeat("0", 64), "zero"},
+		{"curve order", "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", "curve order"},
+		{"above curve order", strings.Repeat("f", 64), "curve order"},
+		{"short", "0123", "expected 32 bytes, got 2"},
+		{"long", strings.Repeat("01", 33), "expected 32 bytes, got 33"},
+		{"not hex", "not_a_hex_key", "not hexadecimal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePrivateKey(tt.key)
+			if !errors.Is(err, ErrInvalidPrivateKey) || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("Expected ErrInvalidPrivateKey mentioning %q, got %v", tt.reason, err)
+			}
+		})
+	}
+
+	// An out-of-range scalar, which PrivKeyFromBytes would silently reduce, is
+	// rejected before signing.
+	if _, err := NewAccount().SignData([]byte("data"), strings.Repeat("f", 64)); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Errorf("SignData should reject an out-of-range key, got %v", err)
+	}
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	first, err := KeyFingerprint(testPrivateKey)
+	if err != nil {
+		t.Fatalf("KeyFingerprint failed: %v", err)
+	}
+	again, err := KeyFingerprint("0x" + testPrivateKey)
+	if err != nil {
+		t.Fatalf("KeyFingerprint failed: %v", err)
+	}
+	if first != again || len(first) != 16 {
+		t.Errorf("Fingerprint should be a stable 16-character hash, got %q and %q", first, again)
+	}
+	if strings.Contains(testPrivateKey, first) {
+		t.Error("Fingerprint must not reveal the private key")
+	}
+
+	other, err := KeyFingerprint(testSecondaryPrivateKey)
+	if err != nil {
+		t.Fatalf("KeyFingerprint failed: %v", err)
+	}
+	if other == first {
+		t.Error("Different keys should have different fingerprints")
+	}
+
+	signer, _ := NewKeySigner(testPrivateKey)
+	fromPublic, err := publicKeyFingerprint(signer.PublicKey())
+	if err != nil || fromPublic != first {
+		t.Errorf("Public key fingerprint should match, got %q (%v), expected %q", fromPublic, err, first)
+	}
+
+	if _, err := KeyFingerprint("not-a-key"); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Errorf("Expected ErrInvalidPrivateKey, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNoRentModel is returned by EstimateStorageCost for blockchains that do not
+// charge rent for stored data.
+var ErrNoRentModel = errors.New("blockchain has no rent model")
+
+// EstimateStorageCost estimates the rent charged for keeping a certificate holding
+// pdata on the configured blockchain for durationDays.
+//
+// The estimate is the size of the transaction payload carrying pdata, in bytes,
+// times the per-byte, per-day StorageRate reported by GetAnalytics, times the
+// duration. It returns an error wrapping ErrNoRentModel if the blockchain reports
+// no storage rate, and an error if durationDays is not positive.
+func (a *Account) EstimateStorageCost(pdata []byte, durationDays int) (float64, error) {
+	if durationDays <= 0 {
+		return 0, fmt.Errorf("invalid duration %d: must be at least one day", durationDays)
+	}
+	certificate, err := json.Marshal(newCertificatePayload(pdata))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode certificate payload: %w", err)
+	}
+
+	analytics, err := a.GetAnalytics()
+	if err != nil {
+		return 0, err
+	}
+	rate := analytics.Response.StorageRate
+	if rate <= 0 {
+		return 0, fmt.Errorf("%w: blockchain %s reports no storage rate", ErrNoRentModel, a.blockchain)
+	}
+
+	// The payload is stored hex-encoded, two bytes per byte of certificate JSON.
+	size := 2 * len(certificate)
+	return float64(size) * rate * float64(durationDays), nil
+}
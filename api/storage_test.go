@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+)
+
+// newRentNAGAccount returns an account whose mock NAG reports the given storage rate.
+func newRentNAGAccount(t *testing.T, rate float64) *Account {
+	t.Helper()
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"BlockTime": 5, "ActiveNodes": 3, "TotalNodes": 3, "StorageRate": rate},
+		})
+	})
+}
+
+func TestAccount_EstimateStorageCost(t *testing.T) {
+	account := newRentNAGAccount(t, 0.001)
+
+	small, err := account.EstimateStorageCost([]byte("short"), 30)
+	if err != nil {
+		t.Fatalf("EstimateStorageCost failed: %v", err)
+	}
+	// {"Action":"CP_CERTIFICATE","Data":"73686f7274"} is 47 bytes, stored as 94 hex digits.
+	if expected := 94 * 0.001 * 30; math.Abs(small-expected) > 1e-9 {
+		t.Errorf("Expected cost %v, got %v", expected, small)
+	}
+
+	longer, err := account.EstimateStorageCost([]byte("a much longer certificate"), 30)
+	if err != nil {
+		t.Fatalf("EstimateStorageCost failed: %v", err)
+	}
+	if longer <= small {
+		t.Errorf("Cost should grow with size: %v for more data, %v for less", longer, small)
+	}
+
+	doubled, err := account.EstimateStorageCost([]byte("short"), 60)
+	if err != nil {
+		t.Fatalf("EstimateStorageCost failed: %v", err)
+	}
+	if math.Abs(doubled-2*small) > 1e-9 {
+		t.Errorf("Cost should scale with duration: %v for 60 days, %v for 30", doubled, small)
+	}
+}
+
+func TestAccount_EstimateStorageCostNoRent(t *testing.T) {
+	account := newRentNAGAccount(t, 0)
+
+	if _, err := account.EstimateStorageCost([]byte("data"), 30); !errors.Is(err, ErrNoRentModel) {
+		t.Errorf("Expected ErrNoRentModel, got %v", err)
+	}
+	if _, err := account.EstimateStorageCost([]byte("data"), 0); err == nil {
+		t.Error("A zero duration should be rejected")
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SubmitResult reports the outcome of one item submitted by SubmitStream.
+type SubmitResult struct {
+	Index    int                        // The position of the item in the input stream, from zero.
+	TxID     string                     // The ID of the transaction, if it was built.
+	Nonce    string                     // The nonce of the transaction, if it was built.
+	Response *SubmitCertificateResponse // The NAG's acknowledgment, if one was received.
+	Err      error                      // Why the item could not be submitted, if it failed.
+}
+
+// SubmitStream submits every item received from in as a certificate, for continuous
+// ingestion, and emits a result per item.
+//
+// The nonce is refreshed once, then transactions are built and signed one at a time
+// in input order, so consecutive items carry consecutive nonces. Transactions are
+// sent to the NAG in nonce order, each once the NAG has answered the previous one,
+// so they never reach it out of order; up to concurrency transactions (at least
+// one) are built and signed ahead of their send. Results are emitted as sends
+// complete, identified by their Index. While concurrency transactions await their
+// send, for instance because the NAG throttles and requests are retried, no further
+// item is read from in beyond those buffered (see SetStreamBuffer). An item that fails is
+// reported in its result without stopping the stream; a failed send may leave a gap
+// in the nonces, so call UpdateAccount before submitting again after failures.
+//
+// The results channel is closed once in is closed or ctx is done, and transactions
+// already built have been sent. The error channel receives an error if the stream
+// cannot start or the account shuts down, and is then closed. StopAllWatchers and
+// Close stop the stream as if ctx was cancelled.
+func (a *Account) SubmitStream(ctx context.Context, in <-chan []byte, privateKey string, concurrency int) (<-chan SubmitResult, <-chan error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make(chan SubmitResult)
+	errs := make(chan error, 1)
+
+	ctx, done := a.watchers.start(ctx)
+	go func() {
+		defer done()
+		defer close(errs)
+		defer close(results)
+		if err := a.submitStream(ctx, in, privateKey, concurrency, results); err != nil {
+			errs <- err
+		}
+	}()
+	return results, errs
+}
+
+// submitStream runs a stream started by SubmitStream.
+func (a *Account) submitStream(ctx context.Context, in <-chan []byte, privateKey string, concurrency int, results chan<- SubmitResult) error {
+	if a.walletAddress == "" {
+		return fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return err
+	}
+	_, err := a.updateAccount()
+	a.endSubmission()
+	if err != nil {
+		return err
+	}
+
+	emit := func(result SubmitResult) {
+		select {
+		case results <- result:
+		case <-ctx.Done():
+		}
+	}
+	var sending sync.WaitGroup
+	defer sending.Wait()
+	slots := make(chan struct{}, concurrency)
+	// Each send waits for the previous one, closing sent once it is answered.
+	sent := make(chan struct{})
+	close(sent)
+
+	source := in
+	if buffer := a.streamBuffer; buffer > 0 {
+		// The reader holds one item while the queue is full, so the queue holds one
+		// less than the buffer size.
+		queue := make(chan []byte, buffer-1)
+		go func() {
+			defer close(queue)
+			for {
+				var data []byte
+				select {
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					data = item
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case queue <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		source = queue
+	}
+
+	for index := 0; ; index++ {
+		// Waiting for a send slot before pulling the next item applies backpressure
+		// to the input while the NAG is slow.
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+
+		var pdata []byte
+		select {
+		case <-ctx.Done():
+			return nil
+		case data, ok := <-source:
+			if !ok {
+				return nil
+			}
+			pdata = data
+		}
+
+		tx, err := a.nextStreamTransaction(pdata, privateKey)
+		if errors.Is(err, ErrShuttingDown) {
+			return err
+		}
+		if err != nil {
+			<-slots
+			emit(SubmitResult{Index: index, Err: err})
+			continue
+		}
+
+		prev, next := sent, make(chan struct{})
+		sent = next
+		sending.Add(1)
+		go func(index int, tx *SignedTransaction) {
+			defer sending.Done()
+			defer func() { <-slots }()
+			<-prev
+			resp, _, err := a.sendTransaction(tx)
+			close(next)
+			emit(SubmitResult{Index: index, TxID: tx.ID, Nonce: tx.Nonce, Response: resp, Err: err})
+		}(index, tx)
+	}
+}
+
+// SetStreamBuffer sets how many items SubmitStream may read from its input ahead of
+// sending them, smoothing bursty producers; zero, the default, reads an item only
+// once it can be sent. Items read ahead but not submitted when the stream's context
+// is done are discarded.
+func (a *Account) SetStreamBuffer(size int) {
+	a.streamBuffer = size
+}
+
+// nextStreamTransaction builds and signs the transaction for pdata with the account
+// nonce, and advances the nonce for the next one.
+func (a *Account) nextStreamTransaction(pdata []byte, privateKey string) (*SignedTransaction, error) {
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	tx, err := a.buildCertificateTransaction(pdata, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	a.advanceNonce()
+	return tx, nil
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newStreamNAGAccount returns an account whose mock NAG reports walletNonce and
+// records submitted transactions in the order they arrive.
+func newStreamNAGAccount(t *testing.T, walletNonce int, submitted *[]SignedTransaction) *Account {
+	t.Helper()
+	return newRecordingNAGAccount(t, submitted, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetWalletNonce_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+			return
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": walletNonce}})
+	})
+}
+
+func TestAccount_SubmitStream(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newStreamNAGAccount(t, 10, &submitted)
+
+	const items = 20
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for i := 0; i < items; i++ {
+			in <- []byte(fmt.Sprintf("item %d", i))
+		}
+	}()
+
+	results, errs := account.SubmitStream(context.Background(), in, testPrivateKey, 4)
+	seen := make(map[int]bool)
+	for result := range results {
+		if result.Err != nil {
+			t.Errorf("Item %d failed: %v", result.Index, result.Err)
+			continue
+		}
+		// The wallet nonce is 10, so the first item carries 11.
+		if expected := strconv.Itoa(11 + result.Index); result.Nonce != expected {
+			t.Errorf("Item %d carries nonce %s, expected %s", result.Index, result.Nonce, expected)
+		}
+		if result.Response == nil || result.Response.Response.TxID != result.TxID {
+			t.Errorf("Item %d: unexpected acknowledgment %+v", result.Index, result.Response)
+		}
+		seen[result.Index] = true
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Unexpected stream error: %v", err)
+	}
+
+	if len(seen) != items || len(submitted) != items {
+		t.Errorf("Expected %d results and submissions, got %d and %d", items, len(seen), len(submitted))
+	}
+	for i, tx := range submitted {
+		if expected := strconv.Itoa(11 + i); tx.Nonce != expected {
+			t.Fatalf("Submission %d reached the NAG with nonce %s, expected %s", i, tx.Nonce, expected)
+		}
+	}
+	if account.nonce != strconv.Itoa(11+items) {
+		t.Errorf("The account nonce should follow the stream, got %s", account.nonce)
+	}
+}
+
+func TestAccount_SubmitStreamItemFailure(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newStreamNAGAccount(t, 0, &submitted)
+
+	in := make(chan []byte, 3)
+	in <- []byte("first")
+	in <- nil
+	in <- []byte("third")
+	close(in)
+
+	results, _ := account.SubmitStream(context.Background(), in, testPrivateKey, 1)
+	byIndex := make(map[int]SubmitResult)
+	for result := range results {
+		byIndex[result.Index] = result
+	}
+	if byIndex[1].Err == nil {
+		t.Error("Empty data should fail its item")
+	}
+	if byIndex[0].Nonce != "1" || byIndex[2].Nonce != "2" || byIndex[2].Err != nil {
+		t.Errorf("A failed build should not consume a nonce, got %+v and %+v", byIndex[0], byIndex[2])
+	}
+}
+
+func TestAccount_SubmitStreamCancel(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newStreamNAGAccount(t, 0, &submitted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, errs := account.SubmitStream(ctx, make(chan []byte), testPrivateKey, 2)
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Error("No result should be emitted")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Cancelling the context should close the results")
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Cancellation should not be reported as an error, got %v", err)
+	}
+}
+
+func TestAccount_SubmitStreamCancelBuffered(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newStreamNAGAccount(t, 0, &submitted)
+	account.SetStreamBuffer(4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan []byte)
+	results, errs := account.SubmitStream(ctx, in, testPrivateKey, 1)
+	cancel()
+	for range results {
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Cancellation should not be reported as an error, got %v", err)
+	}
+
+	// The input is never closed, so only a reader that watches ctx has stopped.
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case in <- []byte("late item"):
+		t.Error("The input should not be read once the stream is cancelled")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAccount_SubmitStreamNotConfigured(t *testing.T) {
+	results, errs := NewAccount().SubmitStream(context.Background(), make(chan []byte), testPrivateKey, 1)
+	if err := <-errs; err == nil {
+		t.Error("SubmitStream should fail on an account that is not open")
+	}
+	if _, ok := <-results; ok {
+		t.Error("The results should be closed")
+	}
+}
+
+func TestAccount_SubmitStreamBackpressure(t *testing.T) {
+	const (
+		items       = 24
+		concurrency = 3
+		buffer      = 2
+	)
+	var (
+		mu               sync.Mutex
+		pulled, finished int
+		inFlight, peak   int
+		maxAhead         int
+		throttledIDs     = make(map[string]bool)
+	)
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetWalletNonce_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Nonce": 0}})
+		case strings.Contains(r.URL.Path, "Circular_AddTransaction_"):
+			tx := decodeTransaction(t, r)
+			mu.Lock()
+			throttled := !throttledIDs[tx.ID]
+			throttledIDs[tx.ID] = true
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			if ahead := pulled - finished; ahead > maxAhead {
+				maxAhead = ahead
+			}
+			mu.Unlock()
+
+			// A slow NAG that throttles the first attempt of each submission, so all are retried.
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			if !throttled {
+				finished++
+			}
+			mu.Unlock()
+			if throttled {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			acknowledgeTransaction(t, w, tx)
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+	account.SetStreamBuffer(buffer)
+
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for i := 0; i < items; i++ {
+			in <- []byte(fmt.Sprintf("item %d", i))
+			mu.Lock()
+			pulled++
+			mu.Unlock()
+		}
+	}()
+
+	results, errs := account.SubmitStream(context.Background(), in, testPrivateKey, concurrency)
+	seen := make(map[int]bool)
+	for result := range results {
+		if result.Err != nil {
+			t.Errorf("Item %d failed: %v", result.Index, result.Err)
+		}
+		seen[result.Index] = true
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Unexpected stream error: %v", err)
+	}
+
+	if len(seen) != items {
+		t.Errorf("Expected a result for each of the %d items, got %d", items, len(seen))
+	}
+	if peak > concurrency {
+		t.Errorf("At most %d submissions should be in flight, got %d", concurrency, peak)
+	}
+	// Besides the submissions in flight and the buffered items, the producer may have
+	// handed over one item it has not yet counted.
+	if limit := concurrency + buffer + 1; maxAhead > limit {
+		t.Errorf("At most %d items should be pulled ahead of the NAG, got %d", limit, maxAhead)
+	}
+}
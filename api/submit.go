@@ -0,0 +1,397 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// maxMemoLength is the maximum length, in bytes, of a transaction memo.
+const maxMemoLength = 256
+
+// SubmitCertificateWithMemo submits pdata as a certificate along with a human-readable
+// memo.
+//
+// The memo is stored in a dedicated Memo field of the certificate payload, next to the
+// data, and is therefore covered by the transaction ID and signature. It returns an
+// error if the memo is longer than 256 bytes, and ValidationErrors for invalid
+// arguments as SubmitCertificate does.
+func (a *Account) SubmitCertificateWithMemo(pdata []byte, memo, privateKey string) (*SubmitCertificateResponse, error) {
+	if len(memo) > maxMemoLength {
+		return nil, fmt.Errorf("memo is %d bytes long, exceeding the limit of %d", len(memo), maxMemoLength)
+	}
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	payload := newCertificatePayload(pdata)
+	payload.Memo = memo
+	_, result, err := a.submitPayload(payload, privateKey)
+	return result, err
+}
+
+// maxFilenameLength is the maximum length, in bytes, of a certificate filename.
+const maxFilenameLength = 255
+
+// SubmitCertificateWithMeta submits data as a certificate along with its media type
+// and, optionally, its filename, so recipients can render it correctly.
+//
+// The metadata is stored in a Meta object of the certificate payload, next to the
+// data, and is therefore covered by the transaction ID and signature. It returns
+// ValidationErrors if mimeType is not a valid media type such as "application/pdf",
+// or if filename is longer than 255 bytes or contains a path separator, along with
+// those SubmitCertificate reports for invalid data or keys.
+func (a *Account) SubmitCertificateWithMeta(data []byte, mimeType, filename, privateKey string) (*SubmitCertificateResponse, error) {
+	errs := append(a.validateSubmission(data, privateKey), validateMeta(mimeType, filename)...)
+	if err := errs.asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	payload := newCertificatePayload(data)
+	payload.Meta = &certificateMeta{MimeType: mimeType, Filename: filename}
+	_, result, err := a.submitPayload(payload, privateKey)
+	return result, err
+}
+
+// validateMeta checks the metadata of a certificate.
+func validateMeta(mimeType, filename string) ValidationErrors {
+	var errs ValidationErrors
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if parts := strings.Split(mediaType, "/"); err != nil || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		errs = append(errs, ValidationError{Field: "mimeType", Reason: "must be a media type such as application/pdf"})
+	}
+	switch {
+	case len(filename) > maxFilenameLength:
+		errs = append(errs, ValidationError{Field: "filename", Reason: fmt.Sprintf("must not exceed %d bytes", maxFilenameLength)})
+	case strings.ContainsAny(filename, "/\\"):
+		errs = append(errs, ValidationError{Field: "filename", Reason: "must not contain a path separator"})
+	}
+	return errs
+}
+
+// SubmitCertificateWithTx submits pdata as a certificate, like SubmitCertificate, and
+// also returns the signed transaction that was sent, so callers can persist it.
+//
+// The transaction is returned whenever it could be built, even if the NAG rejected it.
+func (a *Account) SubmitCertificateWithTx(pdata []byte, privateKey string) (*SignedTransaction, *SubmitCertificateResponse, error) {
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, nil, err
+	}
+	defer a.endSubmission()
+
+	return a.submitPayload(newCertificatePayload(pdata), privateKey)
+}
+
+// SubmitCertificateAutoNonce refreshes the nonce with UpdateAccount if needed and
+// submits pdata as a certificate, sparing callers the separate UpdateAccount call.
+//
+// The nonce is refreshed when it is older than the window set with SetNonceStaleness
+// (by default, always). After a successful submission the nonce is advanced locally,
+// so consecutive submissions within the window need no refresh; after a failed one
+// it is refreshed on the next call.
+func (a *Account) SubmitCertificateAutoNonce(pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	var resp *SubmitCertificateResponse
+	err := a.withFreshNonce(func() (err error) {
+		resp, err = a.submitValidated(pdata, privateKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// withFreshNonce runs submit under the submission lock, first refreshing the nonce
+// with UpdateAccount if it is stale. A failed submission marks the nonce stale.
+func (a *Account) withFreshNonce(submit func() error) error {
+	if err := a.beginSubmission(); err != nil {
+		return err
+	}
+	defer a.endSubmission()
+
+	if a.nonceStale() {
+		if _, err := a.updateAccount(); err != nil {
+			return err
+		}
+	}
+
+	if err := submit(); err != nil {
+		a.nonceUpdatedAt = time.Time{}
+		return err
+	}
+	return nil
+}
+
+// ErrNonceMismatch is returned by SubmitCertificateIfNonce when the account nonce is
+// not the expected one, typically because another writer submitted in the meantime.
+var ErrNonceMismatch = errors.New("nonce does not match the expected value")
+
+// SubmitCertificateIfNonce refreshes the nonce with UpdateAccount and submits pdata
+// as a certificate only if the transaction would carry expectedNonce.
+//
+// This gives optimistic-concurrency semantics to several writers sharing a wallet:
+// a writer that lost the race gets an error matching ErrNonceMismatch, and nothing
+// is submitted.
+func (a *Account) SubmitCertificateIfNonce(expectedNonce int, pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	if _, err := a.updateAccount(); err != nil {
+		return nil, err
+	}
+	if a.nonce != strconv.Itoa(expectedNonce) {
+		return nil, fmt.Errorf("%w: expected %d, got %s", ErrNonceMismatch, expectedNonce, a.nonce)
+	}
+	return a.submitValidated(pdata, privateKey)
+}
+
+// safeSubmitAttempts is the number of times SubmitCertificateSafe sends a transaction
+// that is confirmed absent after a transport error.
+const safeSubmitAttempts = 3
+
+// SubmitCertificateSafe submits pdata as a certificate, recovering from transport
+// errors without risking a duplicate submission.
+//
+// The transaction is built and signed once. When sending it fails without a NAG
+// answer, the NAG may still have processed it, so the transaction is first looked up
+// by its ID: if it landed, its acknowledgment is returned; if it is confirmed absent,
+// the very same transaction is sent again, up to three times in total. If the lookup
+// itself fails, the original transport error is returned without resubmitting.
+// Invalid arguments are reported as ValidationErrors, as by SubmitCertificate.
+func (a *Account) SubmitCertificateSafe(pdata []byte, privateKey string) (*SubmitCertificateResponse, error) {
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	tx, err := a.buildCertificateTransaction(pdata, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, raw, err := a.sendTransaction(tx)
+		var statusErr *client.StatusError
+		if err == nil || raw != nil || errors.As(err, &statusErr) {
+			// The NAG answered, so its verdict is final.
+			if err == nil {
+				a.consumeNonce(tx)
+			}
+			return result, err
+		}
+
+		found, lookupErr := a.fetchTransaction(tx.ID, "", "")
+		if lookupErr == nil {
+			a.consumeNonce(tx)
+			resp := &SubmitCertificateResponse{Result: 200, Node: found.Node}
+			resp.Response.TxID = tx.ID
+			resp.Response.Timestamp = tx.Timestamp
+			return resp, nil
+		}
+		if !errors.Is(lookupErr, errTransactionNotFound) || attempt == safeSubmitAttempts {
+			return nil, err
+		}
+	}
+}
+
+// defaultMaxReaderSize is the default limit, in bytes, of SubmitCertificateReader.
+const defaultMaxReaderSize = 1 << 20
+
+// SetMaxReaderSize sets the largest amount of data, in bytes, SubmitCertificateReader
+// accepts. Zero restores the default of 1 MiB.
+func (a *Account) SetMaxReaderSize(n int64) {
+	a.maxReaderSize = n
+}
+
+// SubmitCertificateReader reads the certificate data from r and submits it like
+// SubmitCertificate.
+//
+// At most the size set with SetMaxReaderSize (1 MiB by default) is read: a reader
+// holding more data is rejected without being read further and nothing is submitted.
+func (a *Account) SubmitCertificateReader(r io.Reader, privateKey string) (*SubmitCertificateResponse, error) {
+	limit := a.maxReaderSize
+	if limit <= 0 {
+		limit = defaultMaxReaderSize
+	}
+
+	pdata, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate data: %w", err)
+	}
+	if int64(len(pdata)) > limit {
+		return nil, fmt.Errorf("certificate data exceeds the limit of %d bytes", limit)
+	}
+	return a.SubmitCertificate(pdata, privateKey)
+}
+
+// SubmitHash submits a SHA-256 digest as the certificate data.
+//
+// This supports flows where documents are hashed externally and only the hash is
+// anchored on-chain. The hashHex parameter must be a 64-character hexadecimal digest,
+// optionally prefixed with "0x"; it is submitted as-is (lowercased) without being
+// hashed again. It returns an error if hashHex is not a valid SHA-256 hex digest.
+func (a *Account) SubmitHash(hashHex, privateKey string) (*SubmitCertificateResponse, error) {
+	digest := strings.ToLower(utils.HexFix(hashHex))
+	if len(digest) != 64 {
+		return nil, fmt.Errorf("invalid SHA-256 digest: expected 64 hex characters, got %d", len(digest))
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return nil, fmt.Errorf("invalid SHA-256 digest: %w", err)
+	}
+	return a.SubmitCertificate([]byte(digest), privateKey)
+}
+
+// timestampLayout is the layout of transaction timestamps, YYYY:MM:DD-HH:MM:SS in UTC.
+const timestampLayout = "2006:01:02-15:04:05"
+
+// SubmitCertificateAt submits pdata as a certificate, like SubmitCertificate, but
+// stamps the transaction with ts instead of the current time.
+//
+// Since the transaction ID covers the timestamp, submitting the same data with the
+// same nonce and timestamp yields the same ID, which makes pipelines and tests
+// reproducible. The ts parameter must use the YYYY:MM:DD-HH:MM:SS layout.
+func (a *Account) SubmitCertificateAt(pdata []byte, privateKey, ts string) (*SubmitCertificateResponse, error) {
+	if _, err := time.Parse(timestampLayout, ts); err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: expected the YYYY:MM:DD-HH:MM:SS layout", ts)
+	}
+	if err := a.validateSubmission(pdata, privateKey).asError(); err != nil {
+		return nil, err
+	}
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	tx, err := a.newTransactionAt(newCertificatePayload(pdata), ts)
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature, err = a.signTransactionID(tx.ID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := a.sendTransaction(tx)
+	if err == nil {
+		a.consumeNonce(tx)
+	}
+	return result, err
+}
+
+// GetTransactionOutcomeRetry waits for the outcome of txID like GetTransactionOutcome,
+// for transactions that may confirm slower than a single timeout window.
+//
+// It polls in successive windows of perAttemptSec seconds, starting a new window
+// whenever one times out, until the outcome is known or totalSec seconds have been
+// spent; the last window is shortened to fit. Errors other than a timeout end the
+// wait immediately. It returns an error matching ErrOutcomeTimeout if the budget is
+// exhausted, and an error if either duration is not positive.
+func (a *Account) GetTransactionOutcomeRetry(txID string, perAttemptSec, totalSec int) (*TransactionResponse, error) {
+	if perAttemptSec <= 0 || totalSec <= 0 {
+		return nil, fmt.Errorf("invalid timeouts %ds per attempt and %ds in total: must be positive", perAttemptSec, totalSec)
+	}
+
+	var err error
+	for remaining := totalSec; remaining > 0; remaining -= perAttemptSec {
+		window := perAttemptSec
+		if window > remaining {
+			window = remaining
+		}
+		var resp *TransactionResponse
+		resp, err = a.GetTransactionOutcome(txID, window)
+		if !errors.Is(err, ErrOutcomeTimeout) {
+			return resp, err
+		}
+	}
+	return nil, fmt.Errorf("transaction %s still pending after %ds: %w", txID, totalSec, err)
+}
+
+// EncodeTransactionWire encodes tx in wire form: the hex encoding of the exact JSON
+// body that SubmitCertificate posts to the NAG's AddTransaction endpoint. It lets a
+// signed transaction travel through other transports, such as message queues or
+// manual relaying, before being sent with SubmitWire.
+func (a *Account) EncodeTransactionWire(tx *SignedTransaction) (string, error) {
+	if tx == nil {
+		return "", fmt.Errorf("transaction is nil")
+	}
+	body, err := json.Marshal(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return hex.EncodeToString(body), nil
+}
+
+// SubmitWire sends a transaction encoded by EncodeTransactionWire to the NAG, with
+// the same result as the submission that built it would have had. It returns an
+// error if wire is not a transaction in wire form.
+func (a *Account) SubmitWire(wire string) (*SubmitCertificateResponse, error) {
+	body, err := hex.DecodeString(utils.HexFix(wire))
+	if err != nil {
+		return nil, fmt.Errorf("invalid wire transaction: %w", err)
+	}
+	var tx SignedTransaction
+	if err := json.Unmarshal(body, &tx); err != nil {
+		return nil, fmt.Errorf("invalid wire transaction: %w", err)
+	}
+	if tx.ID == "" || tx.Signature == "" {
+		return nil, fmt.Errorf("invalid wire transaction: missing ID or signature")
+	}
+
+	if err := a.beginSubmission(); err != nil {
+		return nil, err
+	}
+	defer a.endSubmission()
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	result, _, err := a.sendTransaction(&tx)
+	if err == nil {
+		a.consumeNonce(&tx)
+	}
+	return result, err
+}
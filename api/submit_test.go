@@ -0,0 +1,488 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// newSubmitNAGAccount returns an account whose mock NAG accepts every transaction
+// and records it in *submitted.
+func newSubmitNAGAccount(t *testing.T, submitted *[]SignedTransaction) *Account {
+	t.Helper()
+	account := newRecordingNAGAccount(t, submitted, nil)
+	account.nonce = "1"
+	return account
+}
+
+// certificateData extracts the certificate data from a transaction payload.
+func certificateData(t *testing.T, payload string) string {
+	t.Helper()
+	var certificate struct {
+		Action string `json:"Action"`
+		Data   string `json:"Data"`
+	}
+	if err := json.Unmarshal([]byte(utils.HexToString(payload)), &certificate); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	return utils.HexToString(certificate.Data)
+}
+
+func TestAccount_SubmitHash(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	digest := sha256.Sum256([]byte("confidential document"))
+	hashHex := hex.EncodeToString(digest[:])
+
+	if _, err := account.SubmitHash("0x"+hashHex, testPrivateKey); err != nil {
+		t.Fatalf("SubmitHash failed: %v", err)
+	}
+
+	if len(submitted) != 1 {
+		t.Fatalf("Expected 1 submission, got %d", len(submitted))
+	}
+	if data := certificateData(t, submitted[0].Payload); data != hashHex {
+		t.Errorf("Submitted data %q should be the digest %q", data, hashHex)
+	}
+}
+
+func TestAccount_SubmitHashInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"wrong length", "abcdef"},
+		{"non-hex", "zz" + hex.EncodeToString(make([]byte, 31))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var submitted []SignedTransaction
+			account := newSubmitNAGAccount(t, &submitted)
+
+			if _, err := account.SubmitHash(tt.hash, testPrivateKey); err == nil {
+				t.Error("SubmitHash should reject an invalid digest")
+			}
+			if len(submitted) != 0 {
+				t.Error("SubmitHash should not contact the NAG for an invalid digest")
+			}
+		})
+	}
+}
+
+func TestAccount_SubmitCertificateWithMemo(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	if _, err := account.SubmitCertificateWithMemo([]byte("invoice"), "Q3 audit", testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateWithMemo failed: %v", err)
+	}
+
+	var payload certificatePayload
+	if err := json.Unmarshal([]byte(utils.HexToString(submitted[0].Payload)), &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Memo != "Q3 audit" {
+		t.Errorf("Payload memo = %q, expected %q", payload.Memo, "Q3 audit")
+	}
+	if utils.HexToString(payload.Data) != "invoice" {
+		t.Errorf("Payload data = %q, expected %q", utils.HexToString(payload.Data), "invoice")
+	}
+
+	// The memo is part of the hashed payload, so it changes the transaction ID.
+	withMemo, _ := account.newTransaction(payload)
+	payload.Memo = ""
+	withoutMemo, _ := account.newTransaction(payload)
+	if withMemo.ID == withoutMemo.ID {
+		t.Error("The memo should affect the computed transaction ID")
+	}
+}
+
+func TestAccount_SubmitCertificateWithMemoTooLong(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	if _, err := account.SubmitCertificateWithMemo([]byte("data"), strings.Repeat("m", maxMemoLength+1), testPrivateKey); err == nil {
+		t.Error("SubmitCertificateWithMemo should reject an oversized memo")
+	}
+	if len(submitted) != 0 {
+		t.Error("Nothing should be submitted with an oversized memo")
+	}
+}
+
+func TestAccount_SubmitCertificateWithMeta(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	if _, err := account.SubmitCertificateWithMeta([]byte("%PDF-1.7"), "application/pdf", "deed.pdf", testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateWithMeta failed: %v", err)
+	}
+
+	var payload certificatePayload
+	if err := json.Unmarshal([]byte(utils.HexToString(submitted[0].Payload)), &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Meta == nil || payload.Meta.MimeType != "application/pdf" || payload.Meta.Filename != "deed.pdf" {
+		t.Errorf("Payload metadata = %+v, expected application/pdf and deed.pdf", payload.Meta)
+	}
+	if utils.HexToString(payload.Data) != "%PDF-1.7" {
+		t.Errorf("Payload data = %q, expected %q", utils.HexToString(payload.Data), "%PDF-1.7")
+	}
+
+	// The metadata is part of the hashed payload, so it changes the transaction ID.
+	withMeta, _ := account.newTransaction(payload)
+	payload.Meta = nil
+	withoutMeta, _ := account.newTransaction(payload)
+	if withMeta.ID == withoutMeta.ID {
+		t.Error("The metadata should affect the computed transaction ID")
+	}
+}
+
+func TestAccount_SubmitCertificateWithMetaInvalid(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	tests := []struct {
+		mimeType, filename, field string
+	}{
+		{"", "deed.pdf", "mimeType"},
+		{"pdf", "deed.pdf", "mimeType"},
+		{"application/", "deed.pdf", "mimeType"},
+		{"text/plain; charset", "notes.txt", "mimeType"},
+		{"application/pdf", "../deed.pdf", "filename"},
+		{"application/pdf", strings.Repeat("f", maxFilenameLength+1), "filename"},
+	}
+	for _, tt := range tests {
+		_, err := account.SubmitCertificateWithMeta([]byte("data"), tt.mimeType, tt.filename, testPrivateKey)
+		fields := validationFields(t, err)
+		if len(fields) != 1 || fields[0][0] != tt.field {
+			t.Errorf("SubmitCertificateWithMeta(%q, %q): expected a %s error, got %v", tt.mimeType, tt.filename, tt.field, fields)
+		}
+	}
+	if len(submitted) != 0 {
+		t.Error("Nothing should be submitted with invalid metadata")
+	}
+	if _, err := account.SubmitCertificateWithMeta([]byte("notes"), "text/plain; charset=utf-8", "", testPrivateKey); err != nil {
+		t.Errorf("A media type with parameters and no filename should be accepted, got %v", err)
+	}
+}
+
+func TestCertificatePayload_MemoOmitted(t *testing.T) {
+	encoded, _ := json.Marshal(newCertificatePayload([]byte("data")))
+
+	if strings.Contains(string(encoded), "Memo") {
+		t.Errorf("Certificates without a memo should keep the original payload format, got %s", encoded)
+	}
+}
+
+func TestAccount_SubmitCertificateWithTx(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	tx, result, err := account.SubmitCertificateWithTx([]byte("persist me"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateWithTx failed: %v", err)
+	}
+
+	if tx.ID != result.Response.TxID {
+		t.Errorf("Returned transaction ID %q does not match the acknowledged %q", tx.ID, result.Response.TxID)
+	}
+	if !reflect.DeepEqual(*tx, submitted[0]) {
+		t.Errorf("Returned transaction %+v differs from the one sent %+v", *tx, submitted[0])
+	}
+}
+
+// newFlakyNAGAccount returns an account whose mock NAG fails to answer the first
+// failures AddTransaction calls, having recorded the transaction if landed is set.
+// Every AddTransaction call is recorded in *sent.
+func newFlakyNAGAccount(t *testing.T, failures int, landed bool, sent *[]SignedTransaction) *Account {
+	t.Helper()
+	recorded := make(map[string]SignedTransaction)
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_AddTransaction_"):
+			tx := decodeTransaction(t, r)
+			*sent = append(*sent, tx)
+			if len(*sent) <= failures {
+				if landed {
+					recorded[tx.ID] = tx
+				}
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			recorded[tx.ID] = tx
+			acknowledgeTransaction(t, w, tx)
+		case strings.Contains(r.URL.Path, "Circular_GetTransactionbyID_"):
+			var request map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&request)
+			tx, ok := recorded[request["ID"].(string)]
+			if !ok {
+				writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": "Transaction Not Found"})
+				return
+			}
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"ID": tx.ID, "Status": "Pending"},
+				"Node":     "node-1",
+			})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+	account.client.SetRetryAttempts(0)
+	account.nonce = "1"
+	return account
+}
+
+func TestAccount_SubmitCertificateSafeLanded(t *testing.T) {
+	var sent []SignedTransaction
+	account := newFlakyNAGAccount(t, 1, true, &sent)
+
+	resp, err := account.SubmitCertificateSafe([]byte("data"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateSafe failed: %v", err)
+	}
+
+	if len(sent) != 1 {
+		t.Errorf("A transaction that landed should not be resubmitted, got %d submissions", len(sent))
+	}
+	if resp.Result != 200 || resp.Response.TxID != sent[0].ID {
+		t.Errorf("Unexpected response %+v for transaction %s", resp, sent[0].ID)
+	}
+}
+
+func TestAccount_SubmitCertificateSafeResubmits(t *testing.T) {
+	var sent []SignedTransaction
+	account := newFlakyNAGAccount(t, 1, false, &sent)
+
+	resp, err := account.SubmitCertificateSafe([]byte("data"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificateSafe failed: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("An absent transaction should be resubmitted once, got %d submissions", len(sent))
+	}
+	if !reflect.DeepEqual(sent[0], sent[1]) {
+		t.Error("The resubmitted transaction should be identical to the first one")
+	}
+	if resp.Response.TxID != sent[0].ID {
+		t.Errorf("TxID = %q, expected %q", resp.Response.TxID, sent[0].ID)
+	}
+}
+
+func TestAccount_SubmitCertificateSafeGivesUp(t *testing.T) {
+	var sent []SignedTransaction
+	account := newFlakyNAGAccount(t, safeSubmitAttempts, false, &sent)
+
+	if _, err := account.SubmitCertificateSafe([]byte("data"), testPrivateKey); err == nil {
+		t.Error("SubmitCertificateSafe should fail once its attempts are exhausted")
+	}
+	if len(sent) != safeSubmitAttempts {
+		t.Errorf("Expected %d submissions, got %d", safeSubmitAttempts, len(sent))
+	}
+}
+
+func TestAccount_SubmitCertificateEmptyData(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	for _, data := range [][]byte{nil, {}} {
+		if _, err := account.SubmitCertificate(data, testPrivateKey); !errors.Is(err, ErrEmptyData) {
+			t.Errorf("Expected ErrEmptyData, got %v", err)
+		}
+	}
+	if _, err := account.SubmitCertificateWithMemo(nil, "memo", testPrivateKey); !errors.Is(err, ErrEmptyData) {
+		t.Errorf("SubmitCertificateWithMemo: expected ErrEmptyData, got %v", err)
+	}
+	if len(submitted) != 0 {
+		t.Fatalf("Empty certificates should not be sent, got %d", len(submitted))
+	}
+
+	account.SetAllowEmptyData(true)
+	if _, err := account.SubmitCertificate(nil, testPrivateKey); err != nil {
+		t.Fatalf("Empty data should be submitted once allowed, got %v", err)
+	}
+	if len(submitted) != 1 || certificateData(t, submitted[0].Payload) != "" {
+		t.Errorf("Expected one empty certificate, got %+v", submitted)
+	}
+}
+
+func TestAccount_SubmitCertificateReader(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+	account.SetMaxReaderSize(16)
+
+	if _, err := account.SubmitCertificateReader(strings.NewReader("sixteen bytes!!!"), testPrivateKey); err != nil {
+		t.Fatalf("SubmitCertificateReader failed: %v", err)
+	}
+	if len(submitted) != 1 || certificateData(t, submitted[0].Payload) != "sixteen bytes!!!" {
+		t.Fatalf("Expected the reader's data to be submitted, got %+v", submitted)
+	}
+
+	_, err := account.SubmitCertificateReader(strings.NewReader("seventeen bytes!!"), testPrivateKey)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit of 16 bytes") {
+		t.Errorf("Expected a size limit error, got %v", err)
+	}
+	if len(submitted) != 1 {
+		t.Errorf("Oversized data should not be submitted, got %d submissions", len(submitted))
+	}
+}
+
+func TestAccount_SubmitCertificateAt(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	first, err := account.SubmitCertificateAt([]byte("data"), testPrivateKey, "2024:01:02-03:04:05")
+	if err != nil {
+		t.Fatalf("SubmitCertificateAt failed: %v", err)
+	}
+	// The submission advanced the nonce; reuse the first one.
+	account.nonce = "1"
+	second, err := account.SubmitCertificateAt([]byte("data"), testPrivateKey, "2024:01:02-03:04:05")
+	if err != nil {
+		t.Fatalf("SubmitCertificateAt failed: %v", err)
+	}
+	if first.Response.TxID != second.Response.TxID {
+		t.Errorf("The same inputs and timestamp should yield the same txID, got %s and %s", first.Response.TxID, second.Response.TxID)
+	}
+	if submitted[0].Timestamp != "2024:01:02-03:04:05" {
+		t.Errorf("Expected the given timestamp, got %s", submitted[0].Timestamp)
+	}
+
+	account.nonce = "1"
+	third, err := account.SubmitCertificateAt([]byte("data"), testPrivateKey, "2024:01:02-03:04:06")
+	if err != nil {
+		t.Fatalf("SubmitCertificateAt failed: %v", err)
+	}
+	if third.Response.TxID == first.Response.TxID {
+		t.Error("Different timestamps should yield different txIDs")
+	}
+}
+
+func TestAccount_SubmitCertificateAtInvalidTimestamp(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	for _, ts := range []string{"", "2024-01-02T03:04:05Z", "2024:13:02-03:04:05"} {
+		if _, err := account.SubmitCertificateAt([]byte("data"), testPrivateKey, ts); err == nil {
+			t.Errorf("Expected an error for timestamp %q", ts)
+		}
+	}
+	if len(submitted) != 0 {
+		t.Errorf("Nothing should be submitted for an invalid timestamp, got %d transactions", len(submitted))
+	}
+}
+
+// newPendingNAGAccount returns an account whose mock NAG reports a transaction as
+// pending until confirmAfter has elapsed, and as executed afterwards.
+func newPendingNAGAccount(t *testing.T, confirmAfter time.Duration) *Account {
+	t.Helper()
+	confirmAt := time.Now().Add(confirmAfter)
+	return newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		status := "Pending"
+		if time.Now().After(confirmAt) {
+			status = "Executed"
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"ID": "abc123", "Status": status}})
+	})
+}
+
+func TestAccount_GetTransactionOutcomeRetry(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	// The first one-second window times out; the second one sees the confirmation.
+	account := newPendingNAGAccount(t, 1500*time.Millisecond)
+	resp, err := account.GetTransactionOutcomeRetry("abc123", 1, 3)
+	if err != nil {
+		t.Fatalf("GetTransactionOutcomeRetry failed: %v", err)
+	}
+	if resp.Response.Status != "Executed" {
+		t.Errorf("Expected status Executed, got %q", resp.Response.Status)
+	}
+}
+
+func TestAccount_GetTransactionOutcomeRetryBudgetExhausted(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	account := newPendingNAGAccount(t, time.Hour)
+	start := time.Now()
+	_, err := account.GetTransactionOutcomeRetry("abc123", 1, 2)
+	if !errors.Is(err, ErrOutcomeTimeout) {
+		t.Errorf("Expected ErrOutcomeTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("The total budget of 2s should bound the wait, took %v", elapsed)
+	}
+
+	if _, err := account.GetTransactionOutcomeRetry("abc123", 0, 2); err == nil {
+		t.Error("Expected an error for a non-positive window")
+	}
+}
+
+func TestAccount_TransactionWire(t *testing.T) {
+	original := timestamp
+	timestamp = func() string { return "2025:06:01-12:00:00" }
+	defer func() { timestamp = original }()
+
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	tx, err := account.buildCertificateTransaction([]byte("relayed"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to build transaction: %v", err)
+	}
+	wire, err := account.EncodeTransactionWire(tx)
+	if err != nil {
+		t.Fatalf("EncodeTransactionWire failed: %v", err)
+	}
+
+	var decoded SignedTransaction
+	if err := json.Unmarshal([]byte(utils.HexToString(wire)), &decoded); err != nil {
+		t.Fatalf("failed to decode wire form: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, *tx) {
+		t.Errorf("Wire form does not round-trip: got %+v, expected %+v", decoded, *tx)
+	}
+
+	viaWire, err := account.SubmitWire(wire)
+	if err != nil {
+		t.Fatalf("SubmitWire failed: %v", err)
+	}
+	// The relayed transaction consumed the nonce; reuse it.
+	account.nonce = "1"
+	direct, err := account.SubmitCertificate([]byte("relayed"), testPrivateKey)
+	if err != nil {
+		t.Fatalf("SubmitCertificate failed: %v", err)
+	}
+	if viaWire.Response.TxID != direct.Response.TxID {
+		t.Errorf("SubmitWire and SubmitCertificate should yield the same result, got %s and %s", viaWire.Response.TxID, direct.Response.TxID)
+	}
+	if !reflect.DeepEqual(submitted[0], submitted[1]) {
+		t.Errorf("SubmitWire should send the same transaction as SubmitCertificate, got %+v and %+v", submitted[0], submitted[1])
+	}
+}
+
+func TestAccount_SubmitWireInvalid(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	for _, wire := range []string{"not hex", utils.StringToHex("not json"), utils.StringToHex("{}")} {
+		if _, err := account.SubmitWire(wire); err == nil {
+			t.Errorf("Expected an error for wire form %q", wire)
+		}
+	}
+	if len(submitted) != 0 {
+		t.Errorf("Nothing should be submitted, got %d transactions", len(submitted))
+	}
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// reconnectDelay is the delay before reopening a dropped transaction stream.
+var reconnectDelay = 1 * time.Second
+
+// SetStreamURL configures the Server-Sent Events endpoint used by
+// SubscribeTransactions. An empty URL makes subscriptions fall back to polling.
+func (a *Account) SetStreamURL(streamURL string) {
+	a.streamURL = streamURL
+}
+
+// SubscribeTransactions emits transactions sent from or to any of the given addresses
+// as they occur, until ctx is cancelled.
+//
+// When a stream URL is configured (see SetStreamURL), the account keeps a Server-Sent
+// Events connection open, reconnecting whenever it drops or fails; each event's data
+// line is expected to hold a JSON transaction. The connection is made with the
+// account's HTTP client, and must be established within its request timeout.
+// Otherwise, every poll interval, the blocks created since the previous poll and the
+// pending transaction pool are polled, and each transaction is emitted once. The
+// returned channel is closed when ctx is cancelled or StopAllWatchers is called. It
+// returns an error if no address is given, neither a stream URL nor a network is
+// configured, or the stream URL does not use HTTPS while it is required (see
+// SetRequireHTTPS).
+func (a *Account) SubscribeTransactions(ctx context.Context, addresses []string) (<-chan Transaction, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("at least one address is required")
+	}
+	if a.streamURL == "" {
+		if err := a.requireNetwork(); err != nil {
+			return nil, err
+		}
+	} else if err := a.checkHTTPS(a.network, a.streamURL); err != nil {
+		return nil, err
+	}
+
+	watched := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		watched[strings.ToLower(utils.HexFix(address))] = true
+	}
+	matches := func(tx Transaction) bool {
+		return watched[strings.ToLower(utils.HexFix(tx.From))] || watched[strings.ToLower(utils.HexFix(tx.To))]
+	}
+
+	ctx, done := a.watchers.start(ctx)
+	out := make(chan Transaction)
+	go func() {
+		defer done()
+		defer close(out)
+		if a.streamURL != "" {
+			a.streamTransactions(ctx, addresses, matches, out)
+		} else {
+			a.pollTransactions(ctx, matches, out)
+		}
+	}()
+	return out, nil
+}
+
+// streamTransactions reads the event stream, reconnecting on drop, until ctx is done.
+func (a *Account) streamTransactions(ctx context.Context, addresses []string, matches func(Transaction) bool, out chan<- Transaction) {
+	for {
+		a.readTransactionStream(ctx, addresses, matches, out)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// readTransactionStream consumes a single event stream connection until it ends. A
+// connection that fails is left to the caller to reopen.
+func (a *Account) readTransactionStream(ctx context.Context, addresses []string, matches func(Transaction) bool, out chan<- Transaction) {
+	// The stream is long-lived, so the request timeout bounds establishing it, not
+	// reading it.
+	httpClient, timeout := &http.Client{}, a.requestTimeout()
+	if a.client != nil {
+		httpClient, timeout = a.client.StreamClient(), a.client.Timeout()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streamURL := a.streamURL + "?addresses=" + url.QueryEscape(strings.Join(addresses, ","))
+	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, cancel)
+	}
+	resp, err := httpClient.Do(req)
+	if timer != nil && !timer.Stop() {
+		// The timeout expired and cancelled the connection.
+		if err == nil {
+			resp.Body.Close()
+		}
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		var tx Transaction
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &tx); err != nil || !matches(tx) {
+			continue
+		}
+		select {
+		case out <- tx:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pollTransactions emits matching transactions, each once, until ctx is done.
+//
+// Each poll first scans the blocks created since the previous one, so transactions
+// confirmed between polls are not missed, then the pending transaction pool. Only
+// the IDs of transactions emitted while pending are remembered, until they are found
+// in a block or have left the pool, so memory stays bounded by the size of the pool.
+func (a *Account) pollTransactions(ctx context.Context, matches func(Transaction) bool, out chan<- Transaction) {
+	emit := func(tx Transaction) bool {
+		select {
+		case out <- tx:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// next is the height of the first block not yet scanned, or -1 before the block
+	// count is first known; blocks created before the subscription are not scanned.
+	next := int64(-1)
+	// emitted holds the IDs of the transactions emitted while pending and not yet
+	// found in a block, and pool the IDs of those pending at the previous poll.
+	emitted := make(map[string]bool)
+	pool := make(map[string]bool)
+	for {
+		// A transaction that had left the pool by the previous poll was confirmed, if
+		// at all, in a block created before this poll's block count, so once those
+		// blocks are scanned, or will never be, it is no longer remembered.
+		scanned := next < 0
+		if count, err := a.GetBlockCount(); err == nil {
+			if next < 0 {
+				next = count
+			}
+			for ; next < count; next++ {
+				block, err := a.GetBlock(next)
+				if err != nil {
+					break
+				}
+				for _, tx := range block.Response.Block.Transactions {
+					if emitted[tx.ID] {
+						delete(emitted, tx.ID)
+					} else if matches(tx) && !emit(tx) {
+						return
+					}
+				}
+			}
+			scanned = next == count
+		}
+		if scanned {
+			for id := range emitted {
+				if !pool[id] {
+					delete(emitted, id)
+				}
+			}
+		}
+
+		if pending, err := a.GetPendingTransactions(); err == nil {
+			pool = make(map[string]bool, len(pending))
+			for _, tx := range pending {
+				if !matches(tx) {
+					continue
+				}
+				pool[tx.ID] = true
+				if emitted[tx.ID] {
+					continue
+				}
+				emitted[tx.ID] = true
+				if !emit(tx) {
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
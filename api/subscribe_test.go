@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// receiveTransactions reads n transactions from ch, failing the test on timeout.
+func receiveTransactions(t *testing.T, ch <-chan Transaction, n int) []Transaction {
+	t.Helper()
+	var txs []Transaction
+	for len(txs) < n {
+		select {
+		case tx, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %d transactions", len(txs))
+			}
+			txs = append(txs, tx)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d transactions", len(txs))
+		}
+	}
+	return txs
+}
+
+func TestAccount_SubscribeTransactionsStream(t *testing.T) {
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected an event stream request, got Accept %q", r.Header.Get("Accept"))
+		}
+		connections++
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []map[string]string{
+			{"ID": fmt.Sprintf("tx%d_a", connections), "From": "alice", "To": "bob"},
+			{"ID": fmt.Sprintf("tx%d_b", connections), "From": "carol", "To": "dave"},
+		}
+		for _, event := range events {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: transaction\ndata: %s\n\n", data)
+		}
+		// Returning drops the connection, which the subscriber must reopen.
+	}))
+	defer server.Close()
+
+	defer func(d time.Duration) { reconnectDelay = d }(reconnectDelay)
+	reconnectDelay = 10 * time.Millisecond
+
+	account := NewAccount()
+	account.SetStreamURL(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := account.SubscribeTransactions(ctx, []string{"0xalice"})
+	if err != nil {
+		t.Fatalf("SubscribeTransactions failed: %v", err)
+	}
+
+	txs := receiveTransactions(t, ch, 2)
+	if txs[0].ID != "tx1_a" || txs[1].ID != "tx2_a" {
+		t.Errorf("Expected only alice's transactions across a reconnect, got %q and %q", txs[0].ID, txs[1].ID)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestAccount_SubscribeTransactionsPolling(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		blocks = [][]map[string]interface{}{{}}
+		pool   = []map[string]interface{}{
+			{"ID": "tx1", "From": "alice", "To": "bob"},
+			{"ID": "tx2", "From": "carol", "To": "alice"},
+			{"ID": "tx3", "From": "carol", "To": "dave"},
+		}
+	)
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case strings.Contains(r.URL.Path, "Circular_GetBlockCount_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{"Blocks": len(blocks)}})
+		case strings.Contains(r.URL.Path, "Circular_GetBlock_"):
+			height, _ := strconv.Atoi(request["BlockNumber"].(string))
+			writeNAGResponse(t, w, map[string]interface{}{
+				"Result":   200,
+				"Response": map[string]interface{}{"Block": map[string]interface{}{"BlockNumber": height, "Transactions": blocks[height]}},
+			})
+		case strings.Contains(r.URL.Path, "Circular_GetPendingTransaction_"):
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": pool})
+		default:
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+	})
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := account.SubscribeTransactions(ctx, []string{"alice"})
+	if err != nil {
+		t.Fatalf("SubscribeTransactions failed: %v", err)
+	}
+
+	txs := receiveTransactions(t, ch, 2)
+	if txs[0].ID != "tx1" || txs[1].ID != "tx2" {
+		t.Errorf("Unexpected transactions %q and %q", txs[0].ID, txs[1].ID)
+	}
+
+	// Further polls return the same pool, which must not be emitted again.
+	select {
+	case tx := <-ch:
+		t.Errorf("Transaction %q emitted twice", tx.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// tx1 is confirmed, while tx4 is confirmed without ever being seen pending.
+	mu.Lock()
+	blocks = append(blocks, []map[string]interface{}{
+		{"ID": "tx1", "From": "alice", "To": "bob"},
+		{"ID": "tx4", "From": "alice", "To": "erin"},
+	})
+	pool = pool[1:]
+	mu.Unlock()
+
+	if txs := receiveTransactions(t, ch, 1); txs[0].ID != "tx4" {
+		t.Errorf("Expected the transaction confirmed between polls, got %q", txs[0].ID)
+	}
+	select {
+	case tx := <-ch:
+		t.Errorf("Transaction %q emitted twice", tx.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestAccount_SubscribeTransactionsNotConfigured(t *testing.T) {
+	account := NewAccount()
+
+	if _, err := account.SubscribeTransactions(context.Background(), []string{"alice"}); err == nil {
+		t.Error("SubscribeTransactions should fail without a stream URL or network")
+	}
+	account.SetStreamURL("http://localhost")
+	if _, err := account.SubscribeTransactions(context.Background(), nil); err == nil {
+		t.Error("SubscribeTransactions should fail without addresses")
+	}
+}
+
+func TestAccount_SubscribeTransactionsInsecureStream(t *testing.T) {
+	account := NewAccount()
+	account.SetRequireHTTPS(true)
+	account.SetStreamURL("http://localhost")
+
+	if _, err := account.SubscribeTransactions(context.Background(), []string{"alice"}); !errors.Is(err, ErrInsecureNAGURL) {
+		t.Errorf("Expected ErrInsecureNAGURL for a plain HTTP stream, got %v", err)
+	}
+}
+
+func TestAccount_SubscribeTransactionsStreamTimeout(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A stream that never answers must be abandoned after the request timeout.
+		connections.Add(1)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	defer func(d time.Duration) { reconnectDelay = d }(reconnectDelay)
+	reconnectDelay = 10 * time.Millisecond
+
+	account := NewAccount()
+	account.SetTimeout(20 * time.Millisecond)
+	account.SetStreamURL(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := account.SubscribeTransactions(ctx, []string{"alice"})
+	if err != nil {
+		t.Fatalf("SubscribeTransactions failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for connections.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if connections.Load() < 2 {
+		t.Error("The stream should be reopened once the request timeout expires")
+	}
+
+	cancel()
+	waitClosed(t, ch)
+}
+
+// waitClosed fails the test unless ch is closed promptly, discarding any values.
+func waitClosed(t *testing.T, ch <-chan Transaction) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel was not closed")
+		}
+	}
+}
+
+func TestAccount_StopAllWatchers(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": []map[string]interface{}{}})
+	})
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	var channels []<-chan Transaction
+	for i := 0; i < 3; i++ {
+		ch, err := account.SubscribeTransactions(context.Background(), []string{"alice"})
+		if err != nil {
+			t.Fatalf("SubscribeTransactions failed: %v", err)
+		}
+		channels = append(channels, ch)
+	}
+	if n := account.ActiveWatchers(); n != 3 {
+		t.Errorf("Expected 3 active watchers, got %d", n)
+	}
+
+	account.StopAllWatchers()
+	for _, ch := range channels {
+		waitClosed(t, ch)
+	}
+	if n := account.ActiveWatchers(); n != 0 {
+		t.Errorf("Expected no active watchers after StopAllWatchers, got %d", n)
+	}
+}
+
+func TestAccount_CloseStopsWatchers(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": []map[string]interface{}{}})
+	})
+
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	ch, err := account.SubscribeTransactions(context.Background(), []string{"alice"})
+	if err != nil {
+		t.Fatalf("SubscribeTransactions failed: %v", err)
+	}
+
+	account.Close()
+	waitClosed(t, ch)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/client"
+)
+
+// defaultTimeout is the request timeout, in nanoseconds, inherited by new accounts;
+// zero means the NAG client's own default of 30 seconds.
+var defaultTimeout atomic.Int64
+
+// SetDefaultTimeout sets the request timeout inherited by accounts created afterwards
+// and by the NAG clients of accounts with no timeout of their own, so an application
+// can configure it once at startup. A timeout set with Account.SetTimeout takes
+// precedence. Zero restores the client default of 30 seconds.
+func SetDefaultTimeout(d time.Duration) {
+	defaultTimeout.Store(int64(d))
+}
+
+// SetTimeout sets the timeout of the account's NAG requests, overriding the default
+// set with SetDefaultTimeout. Zero falls back to the default for the clients created
+// afterwards, e.g. by SetNetwork.
+func (a *Account) SetTimeout(d time.Duration) {
+	a.timeout = d
+	if a.client != nil {
+		a.applyTimeout(a.client)
+	}
+}
+
+// requestTimeout returns the timeout the account's requests use, or zero for the
+// client default.
+func (a *Account) requestTimeout() time.Duration {
+	if a.timeout > 0 {
+		return a.timeout
+	}
+	return time.Duration(defaultTimeout.Load())
+}
+
+// applyTimeout configures c with the account's request timeout, if any.
+func (a *Account) applyTimeout(c *client.Client) {
+	if timeout := a.requestTimeout(); timeout > 0 {
+		c.SetTimeout(timeout)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newSlowNAGAccount returns an account, created with NewAccount after applying
+// configure, whose NAG takes delay to answer.
+func newSlowNAGAccount(t *testing.T, delay time.Duration, configure func(*Account)) *Account {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{}})
+	}))
+	t.Cleanup(server.Close)
+
+	account := NewAccount()
+	configure(account)
+	account.network = "testnet"
+	account.client = account.newClient(server.URL)
+	account.client.SetRetryAttempts(0)
+	return account
+}
+
+func TestSetDefaultTimeout(t *testing.T) {
+	SetDefaultTimeout(20 * time.Millisecond)
+	defer SetDefaultTimeout(0)
+
+	account := newSlowNAGAccount(t, 200*time.Millisecond, func(*Account) {})
+	if account.timeout != 20*time.Millisecond {
+		t.Errorf("New account timeout = %v, expected the default of 20ms", account.timeout)
+	}
+	if _, err := account.GetAnalytics(); err == nil {
+		t.Error("A request slower than the default timeout should fail")
+	}
+}
+
+func TestAccount_SetTimeoutOverridesDefault(t *testing.T) {
+	SetDefaultTimeout(20 * time.Millisecond)
+	defer SetDefaultTimeout(0)
+
+	account := newSlowNAGAccount(t, 100*time.Millisecond, func(a *Account) {
+		a.SetTimeout(5 * time.Second)
+	})
+	if _, err := account.GetAnalytics(); err != nil {
+		t.Errorf("The account timeout should take precedence over the default, got %v", err)
+	}
+
+	// Changing the timeout also applies to the account's current client.
+	account.SetTimeout(20 * time.Millisecond)
+	if _, err := account.GetAnalytics(); err == nil {
+		t.Error("A request slower than the new account timeout should fail")
+	}
+}
@@ -1,5 +1,7 @@
 package api
 
+import "encoding/json"
+
 // SubmitCertificateResponse represents the outcome of a certificate submission.
 // It provides the transaction ID and timestamp upon successful submission.
 type SubmitCertificateResponse struct {
@@ -12,33 +14,118 @@ type SubmitCertificateResponse struct {
 	Message string `json:"message"` // An optional message, typically present on error (Result != 200).
 }
 
+// SignedTransaction is a certificate transaction as sent to the NAG's
+// AddTransaction endpoint.
+type SignedTransaction struct {
+	ID         string `json:"ID"`         // The transaction ID, the SHA-256 hex digest of the signed fields.
+	From       string `json:"From"`       // The sender's wallet address.
+	To         string `json:"To"`         // The recipient's wallet address.
+	Timestamp  string `json:"Timestamp"`  // The UTC timestamp of the transaction.
+	Payload    string `json:"Payload"`    // The hexadecimal representation of the certificate payload.
+	Nonce      string `json:"Nonce"`      // The nonce of the sending account.
+	Signature  string `json:"Signature"`  // The DER-encoded signature of the transaction ID, in hex.
+	Blockchain string `json:"Blockchain"` // The blockchain the transaction targets.
+	Type       string `json:"Type"`       // The type of transaction (e.g., "C_TYPE_CERTIFICATE").
+	Version    string `json:"Version"`    // The version of the library that built the transaction.
+	// SecondarySignature is an optional second signature of the ID, by another key of
+	// the same account, used during key rotation. It is omitted when empty.
+	SecondarySignature string `json:"SecondarySignature,omitempty"`
+}
+
 // TransactionResponse represents the detailed outcome of a transaction on the blockchain.
 //
 // This structure is used for responses from methods like GetTransactionOutcome and
 // GetTransactionByID, containing comprehensive details about the transaction
 // including blockchain identifiers, fees, status, and timestamps.
 type TransactionResponse struct {
-	Result   int    `json:"Result"`   // HTTP-like status code indicating the operation's success or failure.
+	Result   int         `json:"Result"`   // HTTP-like status code indicating the operation's success or failure.
+	Response Transaction `json:"Response"` // The transaction details.
+	Node     string      `json:"Node"`     // The address of the node that handled the request.
+	Message  string      `json:"message"`  // An optional message, typically present on error (Result != 200).
+}
+
+// Transaction holds the details of a transaction recorded on the blockchain.
+type Transaction struct {
+	BlockID       string  `json:"BlockID"`       // The identifier of the block in which the transaction was recorded.
+	BroadcastFee  float64 `json:"BroadcastFee"`  // The fee incurred for broadcasting the transaction.
+	DeveloperFee  float64 `json:"DeveloperFee"`  // Any developer fees associated with the transaction.
+	From          string  `json:"From"`          // The blockchain address from which the transaction originated.
+	GasLimit      float64 `json:"GasLimit"`      // The gas limit set for the transaction.
+	ID            string  `json:"ID"`            // The unique identifier of the transaction.
+	Instructions  int     `json:"Instructions"`  // The number of instructions processed by the transaction.
+	NagFee        float64 `json:"NagFee"`        // The Network Access Gateway fee.
+	NodeID        string  `json:"NodeID"`        // The ID of the node that processed the transaction.
+	Nonce         string  `json:"Nonce"`         // The nonce value of the account at the time of the transaction.
+	OSignature    string  `json:"OSignature"`    // The original signature of the transaction.
+	Payload       string  `json:"Payload"`       // The hexadecimal representation of the data payload.
+	ProcessingFee float64 `json:"ProcessingFee"` // The fee for processing the transaction.
+	ProtocolFee   float64 `json:"ProtocolFee"`   // The protocol fee.
+	Status        string  `json:"Status"`        // The execution status of the transaction (e.g., "Executed").
+	Timestamp     string  `json:"Timestamp"`     // The UTC timestamp when the transaction occurred.
+	To            string  `json:"To"`            // The blockchain address to which the transaction was sent.
+	Type          string  `json:"Type"`          // The type of transaction (e.g., "C_TYPE_CERTIFICATE").
+}
+
+// WalletAsset describes a single asset balance held by a wallet.
+type WalletAsset struct {
+	Name   string  `json:"Name"`   // The asset name (e.g., "CIRX").
+	Amount float64 `json:"Amount"` // The amount of the asset held by the wallet.
+}
+
+// WalletResponse represents the content of a wallet as reported by the NAG.
+type WalletResponse struct {
+	Result   int `json:"Result"` // Result code, 200 for success.
 	Response struct {
-		BlockID       string  `json:"BlockID"`       // The identifier of the block in which the transaction was recorded.
-		BroadcastFee  float64 `json:"BroadcastFee"`  // The fee incurred for broadcasting the transaction.
-		DeveloperFee  float64 `json:"DeveloperFee"`  // Any developer fees associated with the transaction.
-		From          string  `json:"From"`          // The blockchain address from which the transaction originated.
-		GasLimit      float64 `json:"GasLimit"`      // The gas limit set for the transaction.
-		ID            string  `json:"ID"`            // The unique identifier of the transaction.
-		Instructions  int     `json:"Instructions"`  // The number of instructions processed by the transaction.
-		NagFee        float64 `json:"NagFee"`        // The Network Access Gateway fee.
-		NodeID        string  `json:"NodeID"`        // The ID of the node that processed the transaction.
-		Nonce         string  `json:"Nonce"`         // The nonce value of the account at the time of the transaction.
-		OSignature    string  `json:"OSignature"`    // The original signature of the transaction.
-		Payload       string  `json:"Payload"`       // The hexadecimal representation of the data payload.
-		ProcessingFee float64 `json:"ProcessingFee"` // The fee for processing the transaction.
-		ProtocolFee   float64 `json:"ProtocolFee"`   // The protocol fee.
-		Status        string  `json:"Status"`        // The execution status of the transaction (e.g., "Executed").
-		Timestamp     string  `json:"Timestamp"`     // The UTC timestamp when the transaction occurred.
-		To            string  `json:"To"`            // The blockchain address to which the transaction was sent.
-		Type          string  `json:"Type"`          // The type of transaction (e.g., "C_TYPE_CERTIFICATE").
+		Address string        `json:"Address"` // The wallet address.
+		Nonce   int           `json:"Nonce"`   // The wallet's current nonce.
+		Assets  []WalletAsset `json:"Assets"`  // The assets held by the wallet.
 	} `json:"Response"`
 	Node    string `json:"Node"`    // The address of the node that handled the request.
 	Message string `json:"message"` // An optional message, typically present on error (Result != 200).
-}
\ No newline at end of file
+}
+
+// AnalyticsResponse represents the network statistics reported by the NAG.
+type AnalyticsResponse struct {
+	Result   int `json:"Result"` // Result code, 200 for success.
+	Response struct {
+		BlockTime           float64 `json:"BlockTime"`           // The average time between blocks, in seconds.
+		PendingTransactions int     `json:"PendingTransactions"` // The number of transactions waiting to be processed.
+		ActiveNodes         int     `json:"ActiveNodes"`         // The number of nodes currently online.
+		TotalNodes          int     `json:"TotalNodes"`          // The number of nodes registered on the network.
+		// StorageRate is the rent charged per stored byte and per day, for blockchains
+		// that charge rent; it is zero for those that do not.
+		StorageRate float64 `json:"StorageRate,omitempty"`
+		// Timestamp is the NAG's current time, in the YYYY:MM:DD-HH:MM:SS layout of
+		// transaction timestamps, if reported.
+		Timestamp string `json:"Timestamp,omitempty"`
+	} `json:"Response"`
+	Node    string `json:"Node"`    // The address of the node that handled the request.
+	Message string `json:"message"` // An optional message, typically present on error (Result != 200).
+}
+
+// Block holds the content of a block of the blockchain.
+type Block struct {
+	BlockID      string        `json:"BlockID"`      // The hash identifying the block.
+	BlockNumber  json.Number   `json:"BlockNumber"`  // The height of the block in the chain.
+	PreviousHash string        `json:"PreviousHash"` // The hash of the preceding block.
+	Timestamp    string        `json:"Timestamp"`    // The UTC timestamp when the block was created.
+	Transactions []Transaction `json:"Transactions"` // The transactions recorded in the block, in order.
+}
+
+// BlockResponse represents a block as reported by the NAG.
+type BlockResponse struct {
+	Result   int `json:"Result"` // Result code, 200 for success.
+	Response struct {
+		Block Block `json:"Block"` // The requested block.
+	} `json:"Response"`
+	Node    string `json:"Node"`    // The address of the node that handled the request.
+	Message string `json:"message"` // An optional message, typically present on error (Result != 200).
+}
+
+// BlockchainInfo describes a blockchain hosted on the network.
+type BlockchainInfo struct {
+	Address string                 `json:"Address"`           // The address identifying the blockchain.
+	Name    string                 `json:"Name"`              // The human-readable name of the blockchain.
+	Network string                 `json:"Network,omitempty"` // The network the blockchain belongs to, if reported.
+	Genesis map[string]interface{} `json:"Genesis,omitempty"` // The genesis block details, if reported.
+}
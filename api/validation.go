@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// ValidationError reports an invalid argument, identified by the name of the field
+// or parameter it was supplied as, so callers can map it back to their input.
+type ValidationError struct {
+	Field  string // The name of the invalid field, e.g. "address" or "privateKey".
+	Reason string // Why the value was rejected, e.g. "must not be empty".
+	err    error  // An optional sentinel error the validation error wraps.
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// Unwrap returns the sentinel error wrapped by e, if any, for errors.Is.
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+// ValidationErrors collects every validation failure of a call. Validating methods
+// return a non-empty ValidationErrors as their error, which can be inspected with
+// errors.As.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual validation errors, for errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// asError returns e as an error, or nil if there are no validation errors.
+func (e ValidationErrors) asError() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// validateAddress checks a wallet address supplied as field.
+func validateAddress(field, address string) ValidationErrors {
+	switch {
+	case strings.TrimSpace(address) == "":
+		return ValidationErrors{{Field: field, Reason: "must not be empty"}}
+	case strings.ContainsAny(address, " \t\r\n"):
+		return ValidationErrors{{Field: field, Reason: "must not contain whitespace"}}
+	}
+	return nil
+}
+
+// validateNetwork checks a network name, which is used in NAG endpoint names.
+func validateNetwork(network string) ValidationErrors {
+	if network == "" {
+		return ValidationErrors{{Field: "network", Reason: "must not be empty"}}
+	}
+	for _, r := range network {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return ValidationErrors{{Field: "network", Reason: "must only contain letters, digits, '-' and '_'"}}
+		}
+	}
+	return nil
+}
+
+// emptyDataError is the validation error for empty certificate data.
+func emptyDataError() ValidationErrors {
+	return ValidationErrors{{Field: "data", Reason: "must not be empty", err: ErrEmptyData}}
+}
+
+// validateSubmission checks the arguments of a certificate submission. The private
+// key is only checked when it will be used to sign, that is when the account is
+// connected to a NAG and has no Signer.
+func (a *Account) validateSubmission(pdata []byte, privateKey string) ValidationErrors {
+	var errs ValidationErrors
+	if len(pdata) == 0 && !a.allowEmptyData {
+		errs = append(errs, emptyDataError()...)
+	}
+	if a.client != nil && a.signer == nil {
+		switch {
+		case privateKey == "" && a.signingKey == nil:
+			errs = append(errs, ValidationError{Field: "privateKey", Reason: "must not be empty"})
+		case privateKey != "":
+			if _, err := hex.DecodeString(utils.HexFix(privateKey)); err != nil {
+				errs = append(errs, ValidationError{Field: "privateKey", Reason: "must be hexadecimal"})
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// validationFields returns the field/reason pairs of a ValidationErrors error.
+func validationFields(t *testing.T, err error) [][2]string {
+	t.Helper()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got %T: %v", err, err)
+	}
+	pairs := make([][2]string, len(errs))
+	for i, e := range errs {
+		pairs[i] = [2]string{e.Field, e.Reason}
+	}
+	return pairs
+}
+
+func TestAccount_OpenValidation(t *testing.T) {
+	tests := map[string][2]string{
+		"":         {"address", "must not be empty"},
+		"   ":      {"address", "must not be empty"},
+		"0xab cd":  {"address", "must not contain whitespace"},
+		"0xabcd\n": {"address", "must not contain whitespace"},
+	}
+
+	for address, expected := range tests {
+		account := NewAccount()
+		pairs := validationFields(t, account.Open(address))
+		if !reflect.DeepEqual(pairs, [][2]string{expected}) {
+			t.Errorf("Open(%q): got %v, expected %v", address, pairs, expected)
+		}
+		if account.walletAddress != "" {
+			t.Errorf("Open(%q) should not open the account", address)
+		}
+	}
+}
+
+func TestAccount_SetNetworkValidation(t *testing.T) {
+	tests := map[string][2]string{
+		"":            {"network", "must not be empty"},
+		"test net":    {"network", "must only contain letters, digits, '-' and '_'"},
+		"testnet?x=1": {"network", "must only contain letters, digits, '-' and '_'"},
+	}
+
+	for network, expected := range tests {
+		account := NewAccount()
+		pairs := validationFields(t, account.SetNetwork(network))
+		if !reflect.DeepEqual(pairs, [][2]string{expected}) {
+			t.Errorf("SetNetwork(%q): got %v, expected %v", network, pairs, expected)
+		}
+	}
+
+	pairs := validationFields(t, NewAccount().SetNetworkTyped("testent"))
+	if len(pairs) != 1 || pairs[0][0] != "network" {
+		t.Errorf("SetNetworkTyped: got %v, expected a network error", pairs)
+	}
+}
+
+func TestAccount_SubmitCertificateValidation(t *testing.T) {
+	var submitted []SignedTransaction
+	account := newSubmitNAGAccount(t, &submitted)
+
+	_, err := account.SubmitCertificate(nil, "")
+	expected := [][2]string{{"data", "must not be empty"}, {"privateKey", "must not be empty"}}
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("Got %v, expected %v", pairs, expected)
+	}
+	if !errors.Is(err, ErrEmptyData) {
+		t.Error("The empty data error should match ErrEmptyData")
+	}
+
+	_, err = account.SubmitCertificate([]byte("data"), "not-hex")
+	expected = [][2]string{{"privateKey", "must be hexadecimal"}}
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("Got %v, expected %v", pairs, expected)
+	}
+
+	_, err = account.SubmitCertificateWithMemo([]byte("data"), "memo", "not-hex")
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("SubmitCertificateWithMemo: got %v, expected %v", pairs, expected)
+	}
+	_, _, err = account.SubmitCertificateWithTx([]byte("data"), "not-hex")
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("SubmitCertificateWithTx: got %v, expected %v", pairs, expected)
+	}
+	_, err = account.SubmitCertificateSafe([]byte("data"), "not-hex")
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("SubmitCertificateSafe: got %v, expected %v", pairs, expected)
+	}
+	_, err = account.SubmitCertificateWithMeta(nil, "application/pdf", "deed.pdf", "")
+	metaExpected := [][2]string{{"data", "must not be empty"}, {"privateKey", "must not be empty"}}
+	if pairs := validationFields(t, err); !reflect.DeepEqual(pairs, metaExpected) {
+		t.Errorf("SubmitCertificateWithMeta: got %v, expected %v", pairs, metaExpected)
+	}
+	if !errors.Is(err, ErrEmptyData) {
+		t.Error("SubmitCertificateWithMeta: the empty data error should match ErrEmptyData")
+	}
+
+	if len(submitted) != 0 {
+		t.Errorf("Invalid submissions should not be sent, got %d", len(submitted))
+	}
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	single := ValidationErrors{{Field: "address", Reason: "must not be empty"}}
+	if single.Error() != "address: must not be empty" {
+		t.Errorf("Unexpected message %q", single.Error())
+	}
+
+	multiple := append(single, ValidationError{Field: "network", Reason: "must not be empty"})
+	if multiple.Error() != "validation failed: address: must not be empty; network: must not be empty" {
+		t.Errorf("Unexpected message %q", multiple.Error())
+	}
+}
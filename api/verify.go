@@ -0,0 +1,118 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// ErrInvalidSignature is reported by VerifySignaturesBatch for a well-formed
+// signature that does not match the data and public key.
+var ErrInvalidSignature = errors.New("signature does not match the data and public key")
+
+// VerifyItem is a signature to check with VerifySignaturesBatch.
+type VerifyItem struct {
+	PublicKey string // The hex-encoded public key of the signer.
+	Data      []byte // The signed data.
+	Signature string // The hex-encoded DER signature of the SHA-256 digest of Data.
+}
+
+// VerifySignaturesBatch verifies many signatures in parallel, using at most one
+// goroutine per CPU.
+//
+// It returns one error per item, at the same index: nil if the signature is valid,
+// ErrInvalidSignature if it does not match, or a parsing error if the key or
+// signature is malformed.
+func VerifySignaturesBatch(items []VerifyItem) []error {
+	results := make([]error, len(items))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item := items[i]
+				valid, err := verifySignature(item.Data, item.Signature, item.PublicKey)
+				if err == nil && !valid {
+					err = ErrInvalidSignature
+				}
+				results[i] = err
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// VerifyBlock fetches the block at height blockNum of the configured blockchain and
+// verifies the signature of each of its transactions, so validators and auditors can
+// check a block independently of the network.
+//
+// The ID of each transaction is recomputed from its fields, as described in
+// SigningPreimage, and its signature of the ID is checked against the public key
+// pubKeyResolver returns for its sender. It returns one error per transaction, at the
+// same index: nil if the transaction verifies, or why it does not, wrapping
+// ErrInvalidSignature for a signature that does not match. The second error is
+// reported only if the block cannot be fetched.
+func (a *Account) VerifyBlock(blockNum int, pubKeyResolver func(addr string) (string, error)) ([]error, error) {
+	if pubKeyResolver == nil {
+		return nil, errors.New("no public key resolver")
+	}
+	block, err := a.GetBlock(int64(blockNum))
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := block.Response.Block.Transactions
+	results := make([]error, len(transactions))
+	var (
+		items   []VerifyItem
+		indexes []int
+	)
+	for i, tx := range transactions {
+		signed := &SignedTransaction{
+			Blockchain: utils.HexFix(a.blockchain),
+			From:       tx.From,
+			To:         tx.To,
+			Payload:    tx.Payload,
+			Nonce:      tx.Nonce,
+			Timestamp:  tx.Timestamp,
+		}
+		id := sha256.Sum256([]byte(a.SigningPreimage(signed)))
+		if hex.EncodeToString(id[:]) != strings.ToLower(utils.HexFix(tx.ID)) {
+			results[i] = fmt.Errorf("transaction %s: ID does not match the transaction fields", tx.ID)
+			continue
+		}
+		publicKey, err := pubKeyResolver(tx.From)
+		if err != nil {
+			results[i] = fmt.Errorf("transaction %s: failed to resolve the public key of %s: %w", tx.ID, tx.From, err)
+			continue
+		}
+		items = append(items, VerifyItem{PublicKey: publicKey, Data: []byte(utils.HexFix(tx.ID)), Signature: tx.OSignature})
+		indexes = append(indexes, i)
+	}
+
+	for j, err := range VerifySignaturesBatch(items) {
+		if err != nil {
+			i := indexes[j]
+			results[i] = fmt.Errorf("transaction %s: %w", transactions[i].ID, err)
+		}
+	}
+	return results, nil
+}
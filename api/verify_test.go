@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+func TestVerifySignaturesBatch(t *testing.T) {
+	key, err := parsePrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatalf("invalid test key: %v", err)
+	}
+	signer, _ := NewKeySigner(testPrivateKey)
+	other, _ := NewKeySigner(testSecondaryPrivateKey)
+
+	var items []VerifyItem
+	for i := 0; i < 200; i++ {
+		data := []byte(fmt.Sprintf("certificate %d", i))
+		item := VerifyItem{PublicKey: signer.PublicKey(), Data: data, Signature: hex.EncodeToString(signMessage(key, data))}
+		switch i % 4 {
+		case 1:
+			item.Data = []byte("tampered")
+		case 2:
+			item.PublicKey = other.PublicKey()
+		case 3:
+			item.Signature = "not hex"
+		}
+		items = append(items, item)
+	}
+
+	results := VerifySignaturesBatch(items)
+	if len(results) != len(items) {
+		t.Fatalf("Expected %d results, got %d", len(items), len(results))
+	}
+	for i, err := range results {
+		switch i % 4 {
+		case 0:
+			if err != nil {
+				t.Errorf("Item %d: expected a valid signature, got %v", i, err)
+			}
+		case 1, 2:
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Item %d: expected ErrInvalidSignature, got %v", i, err)
+			}
+		case 3:
+			if err == nil || errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Item %d: expected a parsing error, got %v", i, err)
+			}
+		}
+	}
+}
+
+func TestVerifySignaturesBatchEmpty(t *testing.T) {
+	if results := VerifySignaturesBatch(nil); len(results) != 0 {
+		t.Errorf("Expected no results, got %v", results)
+	}
+}
+
+func TestAccount_VerifyBlock(t *testing.T) {
+	var transactions []map[string]interface{}
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "Circular_GetBlock_") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"Block": map[string]interface{}{"BlockNumber": "7", "Transactions": transactions}},
+		})
+	})
+	account.nonce = "5"
+
+	for _, data := range []string{"valid", "tampered payload", "forged signature"} {
+		tx, err := account.newCertificateTransaction([]byte(data))
+		if err != nil {
+			t.Fatalf("failed to build transaction: %v", err)
+		}
+		key := testPrivateKey
+		if data == "forged signature" {
+			key = testSecondaryPrivateKey
+		}
+		signature, err := signTransactionIDWithKey(tx.ID, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		if data == "tampered payload" {
+			tx.Payload = utils.StringToHex("altered")
+		}
+		transactions = append(transactions, map[string]interface{}{
+			"ID": tx.ID, "From": tx.From, "To": tx.To, "Payload": tx.Payload,
+			"Nonce": tx.Nonce, "Timestamp": tx.Timestamp, "OSignature": signature,
+		})
+	}
+
+	signer, _ := NewKeySigner(testPrivateKey)
+	var resolved []string
+	results, err := account.VerifyBlock(7, func(addr string) (string, error) {
+		resolved = append(resolved, addr)
+		return signer.PublicKey(), nil
+	})
+	if err != nil {
+		t.Fatalf("VerifyBlock failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected a result per transaction, got %d", len(results))
+	}
+	if results[0] != nil {
+		t.Errorf("The valid transaction should verify, got %v", results[0])
+	}
+	if results[1] == nil || errors.Is(results[1], ErrInvalidSignature) {
+		t.Errorf("The tampered transaction should fail its ID check, got %v", results[1])
+	}
+	if !errors.Is(results[2], ErrInvalidSignature) {
+		t.Errorf("The forged signature should be reported as invalid, got %v", results[2])
+	}
+	// The tampered transaction is rejected before its sender's key is looked up.
+	if len(resolved) != 2 || resolved[0] != utils.HexFix("0xtest_wallet_address") {
+		t.Errorf("Unexpected resolver calls %v", resolved)
+	}
+}
+
+func TestAccount_VerifyBlockInvalid(t *testing.T) {
+	account := newBlockNAGAccount(t, 1)
+	if _, err := account.VerifyBlock(1, nil); err == nil {
+		t.Error("VerifyBlock should fail without a resolver")
+	}
+
+	// The mock transaction has no fields, so its ID does not match them.
+	results, err := account.VerifyBlock(1, func(string) (string, error) { return "", errors.New("unknown sender") })
+	if err != nil || len(results) != 1 || results[0] == nil {
+		t.Errorf("Expected a per-transaction failure, got %v and %v", results, err)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// libVersion is the version of this library. It is sent as the Version field of
+// every NAG request, so it also identifies the payload format in use.
+const libVersion = "1.0.1"
+
+// Version returns the version of the library.
+func Version() string {
+	return libVersion
+}
+
+// ProtocolVersion queries the NAG for the protocol version it supports.
+//
+// The result is remembered on the account; when it differs from the library version
+// returned by Version, the next certificate submission logs a warning, once per
+// account.
+// It returns an error if the network is not set or the query fails.
+func (a *Account) ProtocolVersion() (string, error) {
+	if err := a.requireNetwork(); err != nil {
+		return "", err
+	}
+
+	payload := map[string]interface{}{
+		"Version": libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetVersion_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return "", fmt.Errorf("failed to get protocol version: %w", err)
+	}
+
+	var result struct {
+		Result   int `json:"Result"`
+		Response struct {
+			Version string `json:"Version"`
+		} `json:"Response"`
+		Message string `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 || result.Response.Version == "" {
+		return "", fmt.Errorf("failed to get protocol version (result %d): %s", result.Result, result.Message)
+	}
+
+	a.protocolVersion = result.Response.Version
+	return result.Response.Version, nil
+}
+
+// warnOnVersionMismatch logs a warning the first time a previously queried NAG
+// protocol version is found to differ from the library version.
+func (a *Account) warnOnVersionMismatch() {
+	if version := a.protocolVersion; version != "" && version != libVersion {
+		a.versionWarning.Do(func() {
+			log.Printf("circular: NAG protocol version %s differs from library version %s", version, libVersion)
+		})
+	}
+}
+
+// VersionMismatchError reports a divergence between the library version and the
+// protocol version supported by the NAG.
+type VersionMismatchError struct {
+	LibraryVersion  string // The version of this library.
+	ProtocolVersion string // The protocol version reported by the NAG.
+	// Incompatible is true when the major versions differ. Otherwise the mismatch
+	// is a warning: payloads are expected to be accepted but may drift.
+	Incompatible bool
+}
+
+// Error implements the error interface.
+func (e *VersionMismatchError) Error() string {
+	level := "warning"
+	if e.Incompatible {
+		level = "incompatible"
+	}
+	return fmt.Sprintf("%s: library version %s, NAG protocol version %s", level, e.LibraryVersion, e.ProtocolVersion)
+}
+
+// SetVersionTolerance sets how many minor versions the library and the NAG may
+// differ by before CheckCompatibility reports a warning. The default is 0.
+func (a *Account) SetVersionTolerance(minorVersions int) {
+	a.versionTolerance = minorVersions
+}
+
+// CheckCompatibility compares the library version with the protocol version
+// reported by the NAG.
+//
+// It returns nil when the versions share a major version and their minor versions
+// differ by no more than the configured tolerance, a *VersionMismatchError with
+// Incompatible set to false when only the minor versions diverge beyond the tolerance,
+// and a *VersionMismatchError with Incompatible set to true when the major versions
+// differ. Patch versions are ignored. Other errors are returned if the NAG cannot be queried.
+func (a *Account) CheckCompatibility() error {
+	protocol, err := a.ProtocolVersion()
+	if err != nil {
+		return err
+	}
+
+	libMajor, libMinor, err := parseVersion(libVersion)
+	if err != nil {
+		return err
+	}
+	nagMajor, nagMinor, err := parseVersion(protocol)
+	if err != nil {
+		return err
+	}
+
+	mismatch := &VersionMismatchError{LibraryVersion: libVersion, ProtocolVersion: protocol}
+	if libMajor != nagMajor {
+		mismatch.Incompatible = true
+		return mismatch
+	}
+	diff := libMinor - nagMinor
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > a.versionTolerance {
+		return mismatch
+	}
+	return nil
+}
+
+// parseVersion extracts the major and minor components of a MAJOR.MINOR[.PATCH] version.
+func parseVersion(version string) (major, minor int, err error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return major, minor, nil
+}
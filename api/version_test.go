@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	version := Version()
+
+	if !regexp.MustCompile(`^\d+\.\d+\.\d+$`).MatchString(version) {
+		t.Errorf("Version() = %q, expected a MAJOR.MINOR.PATCH version", version)
+	}
+	if version != libVersion {
+		t.Errorf("Version() = %q, expected %q", version, libVersion)
+	}
+}
+
+func TestAccount_ProtocolVersion(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "Circular_GetVersion_testnet") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"Version": "1.2.0"},
+		})
+	})
+
+	version, err := account.ProtocolVersion()
+	if err != nil {
+		t.Fatalf("ProtocolVersion failed: %v", err)
+	}
+	if version != "1.2.0" {
+		t.Errorf("ProtocolVersion() = %q, expected %q", version, "1.2.0")
+	}
+}
+
+func TestAccount_ProtocolVersionError(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 118, "message": "unsupported"})
+	})
+
+	if _, err := account.ProtocolVersion(); err == nil {
+		t.Error("ProtocolVersion should fail on a non-200 result")
+	}
+}
+
+func TestAccount_WarnOnVersionMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	account := NewAccount()
+	account.protocolVersion = libVersion
+	account.warnOnVersionMismatch()
+	if buf.Len() != 0 {
+		t.Errorf("No warning expected for matching versions, got %q", buf.String())
+	}
+
+	account.protocolVersion = "9.9.9"
+	account.warnOnVersionMismatch()
+	if !strings.Contains(buf.String(), "9.9.9") {
+		t.Errorf("Expected a version mismatch warning, got %q", buf.String())
+	}
+
+	account.warnOnVersionMismatch()
+	if n := strings.Count(buf.String(), "9.9.9"); n != 1 {
+		t.Errorf("The mismatch should be logged once per account, got %d warnings: %q", n, buf.String())
+	}
+}
+
+func TestAccount_CheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name         string
+		nagVersion   string
+		tolerance    int
+		wantErr      bool
+		incompatible bool
+	}{
+		{"matching versions", libVersion, 0, false, false},
+		{"patch difference", "1.0.9", 0, false, false},
+		{"minor mismatch", "1.3.0", 0, true, false},
+		{"minor mismatch within tolerance", "1.3.0", 3, false, false},
+		{"major mismatch", "2.0.1", 5, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+				writeNAGResponse(t, w, map[string]interface{}{
+					"Result":   200,
+					"Response": map[string]interface{}{"Version": tt.nagVersion},
+				})
+			})
+			account.SetVersionTolerance(tt.tolerance)
+
+			err := account.CheckCompatibility()
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("CheckCompatibility should succeed, got %v", err)
+				}
+				return
+			}
+
+			var mismatch *VersionMismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("Expected *VersionMismatchError, got %v", err)
+			}
+			if mismatch.Incompatible != tt.incompatible {
+				t.Errorf("Incompatible = %v, expected %v", mismatch.Incompatible, tt.incompatible)
+			}
+			if mismatch.ProtocolVersion != tt.nagVersion {
+				t.Errorf("ProtocolVersion = %q, expected %q", mismatch.ProtocolVersion, tt.nagVersion)
+			}
+		})
+	}
+}
+
+func TestAccount_CheckCompatibilityMalformedVersion(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"Version": "latest"},
+		})
+	})
+
+	err := account.CheckCompatibility()
+	var mismatch *VersionMismatchError
+	if err == nil || errors.As(err, &mismatch) {
+		t.Errorf("Expected a parse error for a malformed version, got %v", err)
+	}
+}
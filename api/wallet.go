@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lessuselesss/circular-go-enterprise-apis/internal/utils"
+)
+
+// GetWallet retrieves the content of the account's wallet from the NAG.
+//
+// It returns the full wallet description, including the nonce and every asset
+// the wallet holds, or an error if the account is not open or the query fails.
+func (a *Account) GetWallet() (*WalletResponse, error) {
+	if a.walletAddress == "" {
+		return nil, fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Address":    utils.HexFix(a.walletAddress),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetWallet_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	var result WalletResponse
+	if err := a.decodeTyped(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return nil, fmt.Errorf("failed to get wallet (result %d): %s", result.Result, result.Message)
+	}
+	return &result, nil
+}
+
+// ErrWalletNotFound is matched by the error CheckWallet returns when the NAG answers
+// that the wallet is not registered.
+var ErrWalletNotFound = errors.New("wallet not found")
+
+// CheckWallet reports whether the account's wallet is registered on the account's
+// blockchain.
+//
+// It returns true if the NAG confirms the wallet. If the NAG answers that the wallet
+// is not registered, it returns false and an error wrapping ErrWalletNotFound that
+// carries the NAG's message. Any other failure, whether the NAG cannot be reached or
+// answers with another error result, returns an error that does not match
+// ErrWalletNotFound, so a missing wallet can be told apart from a failed query. It
+// returns an error if the account is not open.
+func (a *Account) CheckWallet() (bool, error) {
+	if a.walletAddress == "" {
+		return false, fmt.Errorf("account is not open")
+	}
+	return a.checkWallet(a.blockchain, a.walletAddress)
+}
+
+// WalletExists reports whether the wallet at address exists on the account's
+// blockchain.
+//
+// It returns false, without error, if the NAG reports no such wallet, and an error
+// if the NAG cannot be queried or answers with any other error result, so a missing
+// wallet is never confused with a failed query. The account does not need to be open.
+func (a *Account) WalletExists(address string) (bool, error) {
+	exists, err := a.checkWallet(a.blockchain, address)
+	if errors.Is(err, ErrWalletNotFound) {
+		return false, nil
+	}
+	return exists, err
+}
+
+// resultWalletNotFound is the Result the NAG answers CheckWallet with when the
+// wallet is not registered.
+const resultWalletNotFound = 118
+
+// checkWallet asks the NAG whether the wallet at address exists on blockchain. It
+// returns an error wrapping ErrWalletNotFound if it does not.
+func (a *Account) checkWallet(blockchain, address string) (bool, error) {
+	if err := a.requireNetwork(); err != nil {
+		return false, err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(blockchain),
+		"Address":    utils.HexFix(address),
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_CheckWallet_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return false, fmt.Errorf("failed to check wallet: %w", err)
+	}
+
+	var result struct {
+		Result   int         `json:"Result"`
+		Response interface{} `json:"Response"`
+		Message  string      `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		// The NAG may explain the failure in either field.
+		message := result.Message
+		if reason, ok := result.Response.(string); ok && message == "" {
+			message = reason
+		}
+		if result.Result == resultWalletNotFound {
+			return false, fmt.Errorf("%w: %s (result %d): %s", ErrWalletNotFound, utils.HexFix(address), result.Result, message)
+		}
+		return false, fmt.Errorf("failed to check wallet (result %d): %s", result.Result, message)
+	}
+	return true, nil
+}
+
+// GetAllBalances returns the balance of every asset held by the account's wallet,
+// keyed by asset name.
+//
+// The balances are taken from a single GetWallet query, avoiding one request per
+// asset. A wallet holding no assets yields an empty, non-nil map.
+func (a *Account) GetAllBalances() (map[string]float64, error) {
+	wallet, err := a.GetWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]float64, len(wallet.Response.Assets))
+	for _, asset := range wallet.Response.Assets {
+		balances[asset.Name] += asset.Amount
+	}
+	return balances, nil
+}
+
+// GetWalletBalance returns the balance the account's wallet holds of asset, as
+// reported by the NAG's wallet balance endpoint.
+//
+// The balance is returned as the decimal number the NAG sent, unchanged, so that
+// large balances keep every digit instead of being rounded to a float64. It returns
+// an error if the account is not open, the query fails, or the response holds no
+// balance.
+func (a *Account) GetWalletBalance(asset string) (string, error) {
+	if a.walletAddress == "" {
+		return "", fmt.Errorf("account is not open")
+	}
+	if err := a.requireNetwork(); err != nil {
+		return "", err
+	}
+
+	payload := map[string]interface{}{
+		"Blockchain": utils.HexFix(a.blockchain),
+		"Address":    utils.HexFix(a.walletAddress),
+		"Asset":      asset,
+		"Version":    libVersion,
+	}
+
+	ctx := context.Background()
+	response, err := a.client.POST(ctx, "Circular_GetWalletBalance_"+a.network, payload)
+	if err != nil {
+		a.lastError = err.Error()
+		return "", fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	var result struct {
+		Result   int `json:"Result"`
+		Response struct {
+			Balance *json.Number `json:"Balance"`
+		} `json:"Response"`
+		Message string `json:"message"`
+	}
+	if err := a.decode(response, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.Result != 200 {
+		return "", fmt.Errorf("failed to get wallet balance (result %d): %s", result.Result, result.Message)
+	}
+	if result.Response.Balance == nil {
+		return "", fmt.Errorf("invalid response format or missing Balance field")
+	}
+	return result.Response.Balance.String(), nil
+}
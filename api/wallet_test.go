@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccount_GetAllBalances(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "Circular_GetWallet_testnet") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result": 200,
+			"Response": map[string]interface{}{
+				"Address": "test_wallet_address",
+				"Nonce":   12,
+				"Assets": []map[string]interface{}{
+					{"Name": "CIRX", "Amount": 1500.25},
+					{"Name": "USDC", "Amount": 42.0},
+					{"Name": "GOLD", "Amount": 0.5},
+				},
+			},
+		})
+	})
+
+	balances, err := account.GetAllBalances()
+	if err != nil {
+		t.Fatalf("GetAllBalances failed: %v", err)
+	}
+
+	expected := map[string]float64{"CIRX": 1500.25, "USDC": 42.0, "GOLD": 0.5}
+	if len(balances) != len(expected) {
+		t.Fatalf("Expected %d balances, got %d: %v", len(expected), len(balances), balances)
+	}
+	for name, amount := range expected {
+		if balances[name] != amount {
+			t.Errorf("Balance of %s: expected %v, got %v", name, amount, balances[name])
+		}
+	}
+}
+
+func TestAccount_GetAllBalancesEmptyWallet(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{
+			"Result":   200,
+			"Response": map[string]interface{}{"Address": "test_wallet_address", "Nonce": 0},
+		})
+	})
+
+	balances, err := account.GetAllBalances()
+	if err != nil {
+		t.Fatalf("GetAllBalances failed: %v", err)
+	}
+	if balances == nil {
+		t.Fatal("GetAllBalances should return an empty map, not nil")
+	}
+	if len(balances) != 0 {
+		t.Errorf("Expected no balances, got %v", balances)
+	}
+}
+
+func TestAccount_GetAllBalancesNotOpen(t *testing.T) {
+	account := NewAccount()
+
+	if _, err := account.GetAllBalances(); err == nil {
+		t.Error("GetAllBalances should fail on an account that is not open")
+	}
+}
+
+func TestAccount_WalletExists(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "Circular_CheckWallet_testnet") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		if payload["Address"] == "existing" {
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": "Success"})
+			return
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 118, "Response": "Wallet Not Found"})
+	})
+
+	exists, err := account.WalletExists("0xexisting")
+	if err != nil {
+		t.Fatalf("WalletExists failed: %v", err)
+	}
+	if !exists {
+		t.Error("Expected the wallet to exist")
+	}
+
+	exists, err = account.WalletExists("0xmissing")
+	if err != nil {
+		t.Fatalf("A missing wallet should not be an error, got %v", err)
+	}
+	if exists {
+		t.Error("Expected the wallet not to exist")
+	}
+}
+
+func TestAccount_WalletExistsErrorResult(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 500, "Response": "Internal Error"})
+	})
+
+	exists, err := account.WalletExists("0xexisting")
+	if err == nil {
+		t.Fatal("Expected an error when the NAG fails the query")
+	}
+	if errors.Is(err, ErrWalletNotFound) {
+		t.Errorf("A failed query should not match ErrWalletNotFound, got %v", err)
+	}
+	if exists {
+		t.Error("Expected the wallet not to be reported as existing")
+	}
+}
+
+func TestAccount_WalletExistsNetworkFailure(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := account.WalletExists("0xexisting"); err == nil {
+		t.Error("Expected an error when the NAG cannot be queried")
+	}
+}
+
+func TestAccount_CheckWallet(t *testing.T) {
+	var blockchains []string
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		blockchains = append(blockchains, payload["Blockchain"].(string))
+		if payload["Blockchain"] == "test_blockchain" {
+			writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": "Success"})
+			return
+		}
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 118, "Response": "Wallet Not Found"})
+	})
+
+	exists, err := account.CheckWallet()
+	if err != nil || !exists {
+		t.Errorf("Expected the wallet to exist on the configured blockchain, got %v (%v)", exists, err)
+	}
+
+	account.SetBlockchain("0xother_blockchain")
+	exists, err = account.CheckWallet()
+	if exists || !errors.Is(err, ErrWalletNotFound) {
+		t.Fatalf("Expected ErrWalletNotFound, got %v (%v)", exists, err)
+	}
+	if !strings.Contains(err.Error(), "Wallet Not Found") {
+		t.Errorf("The error should carry the NAG message, got %v", err)
+	}
+	if blockchains[0] != "test_blockchain" || blockchains[1] != "other_blockchain" {
+		t.Errorf("Unexpected blockchains queried: %v", blockchains)
+	}
+}
+
+func TestAccount_CheckWalletFailures(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := account.CheckWallet(); err == nil || errors.Is(err, ErrWalletNotFound) {
+		t.Errorf("A failed query should not be reported as a missing wallet, got %v", err)
+	}
+
+	if _, err := NewAccount().CheckWallet(); err == nil {
+		t.Error("CheckWallet should fail on an account that is not open")
+	}
+}
+
+func TestAccount_GetWalletBalance(t *testing.T) {
+	var payload map[string]interface{}
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "Circular_GetWalletBalance_testnet") {
+			t.Errorf("Unexpected endpoint %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Result": 200, "Response": {"Balance": 123456789012345678901234.000000001}}`))
+	})
+
+	balance, err := account.GetWalletBalance("CIRX")
+	if err != nil {
+		t.Fatalf("GetWalletBalance failed: %v", err)
+	}
+	if balance != "123456789012345678901234.000000001" {
+		t.Errorf("Balance should keep every digit, got %s", balance)
+	}
+	if payload["Asset"] != "CIRX" || payload["Address"] != "test_wallet_address" {
+		t.Errorf("Unexpected payload %v", payload)
+	}
+}
+
+func TestAccount_GetWalletBalanceMissing(t *testing.T) {
+	account := newMockNAGAccount(t, func(w http.ResponseWriter, r *http.Request) {
+		writeNAGResponse(t, w, map[string]interface{}{"Result": 200, "Response": map[string]interface{}{}})
+	})
+
+	if _, err := account.GetWalletBalance("CIRX"); err == nil || !strings.Contains(err.Error(), "Balance") {
+		t.Errorf("Expected a missing Balance error, got %v", err)
+	}
+	if _, err := NewAccount().GetWalletBalance("CIRX"); err == nil {
+		t.Error("GetWalletBalance should fail on an account that is not open")
+	}
+}
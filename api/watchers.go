@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// watcherRegistry tracks the background goroutines started for subscriptions, so
+// they can all be stopped at once.
+type watcherRegistry struct {
+	mu      sync.Mutex
+	nextID  int
+	cancels map[int]context.CancelFunc
+	running sync.WaitGroup
+}
+
+// start derives a cancellable context from ctx for a new watcher and registers it.
+// The watcher must call the returned done function when it exits.
+func (r *watcherRegistry) start(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.mu.Lock()
+	if r.cancels == nil {
+		r.cancels = make(map[int]context.CancelFunc)
+	}
+	id := r.nextID
+	r.nextID++
+	r.cancels[id] = cancel
+	r.running.Add(1)
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.cancels, id)
+		r.mu.Unlock()
+		cancel()
+		r.running.Done()
+	}
+}
+
+// count returns the number of watchers running.
+func (r *watcherRegistry) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.cancels)
+}
+
+// stopAll cancels every watcher and waits for them to exit.
+func (r *watcherRegistry) stopAll() {
+	r.mu.Lock()
+	for _, cancel := range r.cancels {
+		cancel()
+	}
+	r.mu.Unlock()
+	r.running.Wait()
+}
+
+// ActiveWatchers returns the number of subscriptions started by SubscribeTransactions
+// that are still running.
+func (a *Account) ActiveWatchers() int {
+	return a.watchers.count()
+}
+
+// StopAllWatchers cancels every subscription started by SubscribeTransactions, as if
+// their contexts had been cancelled, and returns once their channels are closed.
+// Close calls it, so no subscription outlives the account.
+func (a *Account) StopAllWatchers() {
+	a.watchers.stopAll()
+}
@@ -1,3 +1,7 @@
 module github.com/lessuselesss/circular-go-enterprise-apis
 
 go 1.23.10
+
+require github.com/btcsuite/btcd/btcec/v2 v2.3.4
+
+require github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -19,8 +21,30 @@ type Client struct {
 	timeout       time.Duration
 	retryAttempts int
 	retryDelay    time.Duration
+	breaker       *circuitBreaker
+	limiter       *rateLimiter
+	// slots, when set, holds one token per request in flight, bounding concurrency.
+	slots    chan struct{}
+	observer Observer
+	// retryableStatuses, when set, replaces the default set of retried status codes.
+	retryableStatuses map[int]bool
+	// retryableErr, when set, decides whether a transport error is retried.
+	retryableErr func(error) bool
+	// codec, when set, encodes request bodies in place of JSON.
+	codec Codec
 }
 
+// Codec encodes request payloads in a wire format other than JSON. Its media type is
+// sent as the Content-Type and Accept headers, and responses declaring it are accepted.
+type Codec interface {
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+}
+
+// Observer is called after every request attempt that received a response, with the
+// raw request and response bodies. It is intended for debugging.
+type Observer func(method, url string, request, response []byte, statusCode int)
+
 // NewClient creates a new HTTP client instance with default configuration.
 // The baseURL parameter specifies the base URL for all API requests.
 func NewClient(baseURL string) *Client {
@@ -41,6 +65,20 @@ func (c *Client) SetTimeout(timeout time.Duration) {
 	c.httpClient.Timeout = timeout
 }
 
+// Timeout returns the timeout duration of HTTP requests.
+func (c *Client) Timeout() time.Duration {
+	return c.timeout
+}
+
+// StreamClient returns an HTTP client for long-lived responses, such as event
+// streams. It shares the transport of c but has no overall request timeout, so a
+// response body may be read for as long as the request's context allows.
+func (c *Client) StreamClient() *http.Client {
+	stream := *c.httpClient
+	stream.Timeout = 0
+	return &stream
+}
+
 // SetRetryAttempts configures the number of retry attempts for failed requests.
 func (c *Client) SetRetryAttempts(attempts int) {
 	c.retryAttempts = attempts
@@ -51,17 +89,134 @@ func (c *Client) SetRetryDelay(delay time.Duration) {
 	c.retryDelay = delay
 }
 
-// POST sends a POST request to the specified endpoint with JSON payload.
+// SetRetryableStatuses sets the HTTP status codes whose responses are retried,
+// replacing the default of every 5xx status and 429 (Too Many Requests). Responses
+// with other non-2xx statuses fail immediately with a *StatusError. Calling it with
+// no codes restores the default.
+func (c *Client) SetRetryableStatuses(codes ...int) {
+	if len(codes) == 0 {
+		c.retryableStatuses = nil
+		return
+	}
+	c.retryableStatuses = make(map[int]bool, len(codes))
+	for _, code := range codes {
+		c.retryableStatuses[code] = true
+	}
+}
+
+// SetRetryableErrorFunc installs a function deciding whether a transport error (a
+// request that received no response) is retried. By default, and when retryable is
+// nil, every transport error is retried.
+func (c *Client) SetRetryableErrorFunc(retryable func(error) bool) {
+	c.retryableErr = retryable
+}
+
+// isRetryableStatus reports whether a response with the given status is retried.
+func (c *Client) isRetryableStatus(code int) bool {
+	if c.retryableStatuses != nil {
+		return c.retryableStatuses[code]
+	}
+	return code >= 500 || code == http.StatusTooManyRequests
+}
+
+// SetCircuitBreaker enables a circuit breaker on the client. After failureThreshold
+// consecutive failed calls the circuit opens and calls fail fast with ErrCircuitOpen.
+// Once cooldown has elapsed a single probe call is let through: if it succeeds the
+// circuit closes, otherwise it stays open for another cooldown. Client errors (4xx)
+// do not count as failures. A failureThreshold of zero or less disables the breaker.
+func (c *Client) SetCircuitBreaker(failureThreshold int, cooldown time.Duration) {
+	if failureThreshold <= 0 {
+		c.breaker = nil
+		return
+	}
+	c.breaker = &circuitBreaker{threshold: failureThreshold, cooldown: cooldown}
+}
+
+// SetRateLimit limits the client to rps requests per second, retries included, by
+// spacing them evenly. A rate of zero or less removes the limit.
+func (c *Client) SetRateLimit(rps float64) {
+	if rps <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / rps)}
+}
+
+// SetMaxConcurrentRequests bounds the number of requests the client has in flight
+// at once to n; excess requests wait for a slot, or until their context is done.
+// Zero or less removes the bound. It must not be called while requests are in flight.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.slots = nil
+		return
+	}
+	c.slots = make(chan struct{}, n)
+}
+
+// SetObserver installs an observer called after every request attempt that received
+// a response. A nil observer disables observation.
+func (c *Client) SetObserver(observer Observer) {
+	c.observer = observer
+}
+
+// SetCodec makes the client encode request payloads with codec and negotiate its media
+// type. A nil codec restores JSON.
+func (c *Client) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
+// contentType returns the media type of request and response bodies.
+func (c *Client) contentType() string {
+	if c.codec == nil {
+		return "application/json"
+	}
+	return c.codec.ContentType()
+}
+
+// CloseIdleConnections closes the connections kept alive for reuse. Later requests
+// open new connections as needed.
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// POST sends a POST request to the specified endpoint with JSON payload, or with the
+// payload encoded by the codec set with SetCodec.
 // It includes built-in retry logic for transient failures.
 func (c *Client) POST(ctx context.Context, endpoint string, payload interface{}) ([]byte, error) {
-	url := c.buildURL(endpoint)
-	
-	// Marshal payload to JSON
-	jsonData, err := json.Marshal(payload)
+	marshal := json.Marshal
+	if c.codec != nil {
+		marshal = c.codec.Marshal
+	}
+	data, err := marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	
+
+	return c.do(ctx, "POST", endpoint, data)
+}
+
+// GET sends a GET request to the specified endpoint.
+// It includes built-in retry logic for transient failures.
+func (c *Client) GET(ctx context.Context, endpoint string) ([]byte, error) {
+	return c.do(ctx, "GET", endpoint, nil)
+}
+
+// do sends a request with the given method, retrying transient failures. A nil body
+// sends no request body. Failed calls are reported to the circuit breaker, if any.
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte) ([]byte, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.doWithRetry(ctx, method, endpoint, body)
+	c.breaker.record(err)
+	return respBody, err
+}
+
+// doWithRetry performs the request and its retries.
+func (c *Client) doWithRetry(ctx context.Context, method, endpoint string, body []byte) ([]byte, error) {
+	url := c.buildURL(endpoint)
+
 	var lastErr error
 	for attempt := 0; attempt <= c.retryAttempts; attempt++ {
 		if attempt > 0 {
@@ -72,104 +227,141 @@ func (c *Client) POST(ctx context.Context, endpoint string, payload interface{})
 			case <-time.After(c.retryDelay):
 			}
 		}
-		
-		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
-			continue
+
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
 		}
-		
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept", "application/json")
-		
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
-			continue
+
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
 		}
-		
-		defer resp.Body.Close()
-		
-		body, err := io.ReadAll(resp.Body)
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 		if err != nil {
-			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			lastErr = fmt.Errorf("failed to create request: %w", err)
 			continue
 		}
-		
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return body, nil
-		}
-		
-		// Handle non-2xx status codes
-		if resp.StatusCode >= 500 {
-			// Server error - retry
-			lastErr = fmt.Errorf("server error (status %d): %s", resp.StatusCode, string(body))
-			continue
-		} else {
-			// Client error - don't retry
-			return nil, fmt.Errorf("client error (status %d): %s", resp.StatusCode, string(body))
+
+		if body != nil {
+			req.Header.Set("Content-Type", c.contentType())
 		}
-	}
-	
-	return nil, fmt.Errorf("request failed after %d attempts: %w", c.retryAttempts+1, lastErr)
-}
+		req.Header.Set("Accept", c.contentType())
 
-// GET sends a GET request to the specified endpoint.
-// It includes built-in retry logic for transient failures.
-func (c *Client) GET(ctx context.Context, endpoint string) ([]byte, error) {
-	url := c.buildURL(endpoint)
-	
-	var lastErr error
-	for attempt := 0; attempt <= c.retryAttempts; attempt++ {
-		if attempt > 0 {
-			// Wait before retry
+		slots := c.slots
+		if slots != nil {
 			select {
+			case slots <- struct{}{}:
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.retryDelay):
 			}
 		}
-		
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
-			continue
+		resp, respBody, err := c.send(req)
+		if slots != nil {
+			<-slots
 		}
-		
-		req.Header.Set("Accept", "application/json")
-		
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
+		if resp == nil {
+			if c.retryableErr != nil && !c.retryableErr(err) {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
 			lastErr = fmt.Errorf("request failed: %w", err)
 			continue
 		}
-		
-		defer resp.Body.Close()
-		
-		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
 			continue
 		}
-		
+
+		if c.observer != nil {
+			c.observer(method, url, body, respBody, resp.StatusCode)
+		}
+
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return body, nil
+			if err := c.checkContentType(resp.Header.Get("Content-Type"), respBody); err != nil {
+				return nil, err
+			}
+			return respBody, nil
 		}
-		
+
 		// Handle non-2xx status codes
-		if resp.StatusCode >= 500 {
-			// Server error - retry
-			lastErr = fmt.Errorf("server error (status %d): %s", resp.StatusCode, string(body))
+		if c.isRetryableStatus(resp.StatusCode) {
+			if resp.StatusCode >= 500 {
+				lastErr = fmt.Errorf("server error (status %d): %s", resp.StatusCode, string(respBody))
+			} else {
+				lastErr = fmt.Errorf("retryable error (status %d): %s", resp.StatusCode, string(respBody))
+			}
 			continue
-		} else {
-			// Client error - don't retry
-			return nil, fmt.Errorf("client error (status %d): %s", resp.StatusCode, string(body))
 		}
+		// Non-retryable status - fail immediately
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
-	
+
 	return nil, fmt.Errorf("request failed after %d attempts: %w", c.retryAttempts+1, lastErr)
 }
 
+// send performs req and reads the response body. The response is nil if the request
+// itself failed.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return resp, body, err
+}
+
+// ErrUnexpectedContentType is matched by the error returned for a successful
+// response that is not JSON, such as the HTML error page of a misconfigured proxy.
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// maxSnippetLength is the number of body bytes quoted in content type errors.
+const maxSnippetLength = 200
+
+// checkContentType returns an error unless contentType declares JSON or the media
+// type of the client's codec.
+func (c *Client) checkContentType(contentType string, body []byte) error {
+	if c.codec != nil {
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == c.codec.ContentType() {
+			return nil
+		}
+	}
+	return checkContentType(contentType, body)
+}
+
+// checkContentType returns an error if contentType declares a body other than JSON.
+// A missing content type and text/plain, which some gateways use for JSON and which
+// Go servers infer for JSON bodies without a header, are accepted.
+func checkContentType(contentType string, body []byte) error {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || mediaType == "text/plain") {
+		return nil
+	}
+
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > maxSnippetLength {
+		snippet = snippet[:maxSnippetLength] + "..."
+	}
+	return fmt.Errorf("%w %q: %s", ErrUnexpectedContentType, contentType, snippet)
+}
+
+// StatusError is returned for responses with a non-retryable, non-2xx status code.
+// By default these are the 4xx statuses other than 429.
+type StatusError struct {
+	StatusCode int    // The HTTP status code of the response.
+	Body       string // The response body.
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	if e.StatusCode >= 500 {
+		return fmt.Sprintf("server error (status %d): %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("client error (status %d): %s", e.StatusCode, e.Body)
+}
+
 // buildURL constructs the full URL by combining base URL and endpoint.
 func (c *Client) buildURL(endpoint string) string {
 	if strings.HasSuffix(c.baseURL, "/") && strings.HasPrefix(endpoint, "/") {
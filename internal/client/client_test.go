@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -36,6 +37,22 @@ func TestClient_SetTimeout(t *testing.T) {
 	}
 }
 
+func TestClient_StreamClient(t *testing.T) {
+	client := NewClient("https://api.example.com")
+	client.SetTimeout(5 * time.Second)
+
+	stream := client.StreamClient()
+	if stream.Timeout != 0 {
+		t.Errorf("StreamClient should have no overall timeout, got %v", stream.Timeout)
+	}
+	if stream.Transport != client.httpClient.Transport {
+		t.Error("StreamClient should share the client's transport")
+	}
+	if client.Timeout() != 5*time.Second || client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("StreamClient should leave the client's timeout unchanged, got %v", client.httpClient.Timeout)
+	}
+}
+
 func TestClient_POST_Success(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -238,3 +255,293 @@ func TestClient_RetryExhausted(t *testing.T) {
 		t.Error("POST should fail after retry attempts exhausted")
 	}
 }
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	failing := true
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetRetryAttempts(0)
+	client.SetCircuitBreaker(2, 50*time.Millisecond)
+
+	// Two consecutive failures open the circuit.
+	for i := 0; i < 2; i++ {
+		if _, err := client.GET(context.Background(), "/test"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected a server error, got %v", i, err)
+		}
+	}
+
+	// While open, calls are rejected without reaching the server.
+	if _, err := client.GET(context.Background(), "/test"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Open circuit should not contact the server, got %d calls", calls)
+	}
+
+	// After the cooldown a successful probe closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+	failing = false
+	if _, err := client.GET(context.Background(), "/test"); err != nil {
+		t.Fatalf("Probe should succeed after cooldown, got %v", err)
+	}
+	if _, err := client.GET(context.Background(), "/test"); err != nil {
+		t.Errorf("Circuit should be closed after a successful probe, got %v", err)
+	}
+}
+
+func TestClient_CircuitBreakerFailedProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetRetryAttempts(0)
+	client.SetCircuitBreaker(1, 20*time.Millisecond)
+
+	client.GET(context.Background(), "/test")
+	time.Sleep(30 * time.Millisecond)
+	if _, err := client.GET(context.Background(), "/test"); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Probe should reach the server and fail, got %v", err)
+	}
+	if _, err := client.GET(context.Background(), "/test"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Circuit should reopen after a failed probe, got %v", err)
+	}
+}
+
+func TestClient_CircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetCircuitBreaker(1, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GET(context.Background(), "/test"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("Client errors should not open the circuit")
+		}
+	}
+}
+
+func TestClient_SetObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var method, url, request, response string
+	var status int
+	client := NewClient(server.URL)
+	client.SetObserver(func(m, u string, req, resp []byte, code int) {
+		method, url, request, response, status = m, u, string(req), string(resp), code
+	})
+
+	if _, err := client.POST(context.Background(), "/observed", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+
+	if method != "POST" || url != server.URL+"/observed" || status != http.StatusOK {
+		t.Errorf("Unexpected observation: %s %s (%d)", method, url, status)
+	}
+	if request != `{"a":"b"}` || response != `{"ok":true}` {
+		t.Errorf("Unexpected bodies: request %q, response %q", request, response)
+	}
+}
+
+// countingServer returns a server answering every request with status, counting
+// the requests in *calls.
+func countingServer(t *testing.T, status int, calls *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_SetRetryableStatuses(t *testing.T) {
+	var conflicts, badRequests int
+	conflictServer := countingServer(t, http.StatusConflict, &conflicts)
+	badRequestServer := countingServer(t, http.StatusBadRequest, &badRequests)
+
+	for _, server := range []*httptest.Server{conflictServer, badRequestServer} {
+		client := NewClient(server.URL)
+		client.SetRetryAttempts(2)
+		client.SetRetryDelay(time.Millisecond)
+		client.SetRetryableStatuses(http.StatusConflict)
+		client.GET(context.Background(), "/test")
+	}
+
+	if conflicts != 3 {
+		t.Errorf("A configured-retryable 409 should be retried, got %d calls", conflicts)
+	}
+	if badRequests != 1 {
+		t.Errorf("A non-configured 400 should not be retried, got %d calls", badRequests)
+	}
+}
+
+func TestClient_RetriesTooManyRequestsByDefault(t *testing.T) {
+	var calls int
+	server := countingServer(t, http.StatusTooManyRequests, &calls)
+
+	client := NewClient(server.URL)
+	client.SetRetryAttempts(1)
+	client.SetRetryDelay(time.Millisecond)
+	if _, err := client.GET(context.Background(), "/test"); err == nil {
+		t.Fatal("GET should fail")
+	}
+	if calls != 2 {
+		t.Errorf("429 should be retried by default, got %d calls", calls)
+	}
+}
+
+func TestClient_SetRetryableErrorFunc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close() // Every request now fails with a transport error.
+
+	var seen int
+	client := NewClient(server.URL)
+	client.SetRetryAttempts(3)
+	client.SetRetryDelay(time.Millisecond)
+	client.SetRetryableErrorFunc(func(err error) bool {
+		seen++
+		return false
+	})
+
+	if _, err := client.GET(context.Background(), "/test"); err == nil {
+		t.Fatal("GET should fail")
+	}
+	if seen != 1 {
+		t.Errorf("A non-retryable transport error should stop after one attempt, got %d", seen)
+	}
+}
+
+func TestClient_CloseIdleConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.GET(context.Background(), "/test"); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+
+	client.CloseIdleConnections()
+	client.CloseIdleConnections()
+	if _, err := client.GET(context.Background(), "/test"); err != nil {
+		t.Errorf("Requests should still work after closing idle connections, got %v", err)
+	}
+}
+
+func TestClient_SetRateLimit(t *testing.T) {
+	var calls int
+	server := countingServer(t, http.StatusOK, &calls)
+
+	client := NewClient(server.URL)
+	client.SetRateLimit(20)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := client.GET(context.Background(), "/test"); err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+	}
+	// Five requests at 20 per second are spaced by 50ms: the last one waits 200ms.
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("Expected the requests to be spaced out, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.SetRateLimit(0.1)
+	client.GET(context.Background(), "/test")
+	if _, err := client.GET(ctx, "/test"); err != context.Canceled {
+		t.Errorf("Expected context.Canceled while waiting for the limiter, got %v", err)
+	}
+}
+
+func TestClient_UnexpectedContentType(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetRetryDelay(time.Millisecond)
+	_, err := client.POST(context.Background(), "/test", map[string]string{})
+	if !errors.Is(err, ErrUnexpectedContentType) {
+		t.Fatalf("Expected ErrUnexpectedContentType, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "text/html") || !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Errorf("Expected the content type and a body snippet in the error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("A non-JSON response should not be retried, got %d calls", calls)
+	}
+}
+
+func TestCheckContentType(t *testing.T) {
+	for _, contentType := range []string{"", "application/json", "application/json; charset=utf-8", "application/problem+json", "text/plain; charset=utf-8"} {
+		if err := checkContentType(contentType, []byte("{}")); err != nil {
+			t.Errorf("checkContentType(%q) should accept JSON, got %v", contentType, err)
+		}
+	}
+
+	long := strings.Repeat("x", 500)
+	err := checkContentType("text/html", []byte(long))
+	if err == nil || strings.Contains(err.Error(), long) || !strings.HasSuffix(err.Error(), "...") {
+		t.Errorf("Expected a truncated snippet, got %v", err)
+	}
+}
+
+// mediaTypeCodec is a test codec sending JSON with a custom media type.
+type mediaTypeCodec struct{}
+
+func (mediaTypeCodec) ContentType() string                   { return "application/x-test" }
+func (mediaTypeCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func TestClient_SetCodec(t *testing.T) {
+	var contentType, accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType, accept = r.Header.Get("Content-Type"), r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/x-test")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.SetRetryAttempts(0)
+	if _, err := client.POST(context.Background(), "/test", map[string]string{}); !errors.Is(err, ErrUnexpectedContentType) {
+		t.Errorf("A JSON client should reject the codec's media type, got %v", err)
+	}
+	if contentType != "application/json" || accept != "application/json" {
+		t.Errorf("Expected JSON headers by default, got Content-Type %q and Accept %q", contentType, accept)
+	}
+
+	client.SetCodec(mediaTypeCodec{})
+	if _, err := client.POST(context.Background(), "/test", map[string]string{}); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	if contentType != "application/x-test" || accept != "application/x-test" {
+		t.Errorf("Expected the codec's media type, got Content-Type %q and Accept %q", contentType, accept)
+	}
+}
@@ -1,16 +1,31 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
 )
 
+// now returns the current time; tests replace it with a fake clock.
+var now = time.Now
+
 // GetFormattedTimeStamp returns the current UTC time formatted as YYYY:MM:DD-HH:MM:SS.
+// It always uses UTC, as transaction signing does, regardless of the local time zone.
 func GetFormattedTimeStamp() string {
-	now := time.Now().UTC()
-	return now.Format("2006:01:02-15:04:05") // Go's reference time for YYYY:MM:DD-HH:MM:SS
+	return GetFormattedTimeStampIn(time.UTC)
+}
+
+// GetFormattedTimeStampIn returns the current time in loc formatted as
+// YYYY:MM:DD-HH:MM:SS. A nil loc means UTC.
+func GetFormattedTimeStampIn(loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return now().In(loc).Format("2006:01:02-15:04:05") // Go's reference time for YYYY:MM:DD-HH:MM:SS
 }
 
 // PadNumber adds a leading zero to numbers less than 10.
@@ -55,3 +70,17 @@ func HexToString(hexStr string) string {
 	}
 	return string(decoded)
 }
+
+// GetKeysFromString derives a key pair and wallet address from a seed string, the
+// way the reference SDKs do: the private key is the SHA-256 of the seed, the public
+// key is its uncompressed secp256k1 public key, and the address is the SHA-256 of the
+// public key's hexadecimal form. All three are returned in hex without "0x" prefix.
+func GetKeysFromString(seed string) (privateKey, publicKey, address string) {
+	keyBytes := sha256.Sum256([]byte(seed))
+	key, pub := btcec.PrivKeyFromBytes(keyBytes[:])
+	defer key.Zero()
+
+	publicKey = hex.EncodeToString(pub.SerializeUncompressed())
+	addressBytes := sha256.Sum256([]byte(publicKey))
+	return hex.EncodeToString(keyBytes[:]), publicKey, hex.EncodeToString(addressBytes[:])
+}
@@ -42,6 +42,24 @@ func TestGetFormattedTimeStampUniqueness(t *testing.T) {
 	}
 }
 
+func TestGetFormattedTimeStampIn(t *testing.T) {
+	instant := time.Date(2024, 3, 9, 22, 30, 5, 0, time.UTC)
+	original := now
+	now = func() time.Time { return instant }
+	defer func() { now = original }()
+
+	if got := GetFormattedTimeStamp(); got != "2024:03:09-22:30:05" {
+		t.Errorf("GetFormattedTimeStamp() = %s; want UTC 2024:03:09-22:30:05", got)
+	}
+	if got := GetFormattedTimeStampIn(nil); got != "2024:03:09-22:30:05" {
+		t.Errorf("GetFormattedTimeStampIn(nil) = %s; want UTC 2024:03:09-22:30:05", got)
+	}
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	if got := GetFormattedTimeStampIn(zone); got != "2024:03:10-00:30:05" {
+		t.Errorf("GetFormattedTimeStampIn(UTC+2) = %s; want 2024:03:10-00:30:05", got)
+	}
+}
+
 func TestPadNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -176,3 +194,24 @@ func TestStringHexRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKeysFromString(t *testing.T) {
+	privateKey, publicKey, address := GetKeysFromString("correct horse battery staple")
+
+	if privateKey != "c4bbcb1fbec99d65bf59d85c8cb62ee2db963f0fe106f483d9afa73bd4e39a8a" {
+		t.Errorf("GetKeysFromString() private key = %s; want the SHA-256 of the seed", privateKey)
+	}
+	if len(publicKey) != 130 || publicKey[:2] != "04" {
+		t.Errorf("GetKeysFromString() public key = %s; want an uncompressed key", publicKey)
+	}
+	if len(address) != 64 {
+		t.Errorf("GetKeysFromString() address = %s; want 64 hex characters", address)
+	}
+
+	if p2, k2, a2 := GetKeysFromString("correct horse battery staple"); p2 != privateKey || k2 != publicKey || a2 != address {
+		t.Error("GetKeysFromString() should be deterministic")
+	}
+	if _, _, other := GetKeysFromString("another seed"); other == address {
+		t.Error("Different seeds should yield different addresses")
+	}
+}